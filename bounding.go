@@ -1,10 +1,18 @@
 package paunch
 
+import (
+	"math"
+)
+
 // bounding is an object that represents a bounding box. It is meant to be
 // used through the Collider interface.
 type bounding struct {
 	start *point
 	end   *point
+
+	tf       *transform
+	oriented *polygon
+	collisionFilter
 }
 
 func newBounding(start *point, end *point) *bounding {
@@ -32,24 +40,75 @@ func (b *bounding) Move(x, y float64) {
 
 	b.start.Move(x, y)
 	b.end.Move(x, y)
+
+	if b.tf != nil {
+		b.tf.anchor.Move(x, y)
+	}
+	if b.oriented != nil {
+		b.oriented.Move(x, y)
+	}
 }
 
 func (b *bounding) SetPosition(x, y float64) {
 
-	width := b.end.x - b.start.x
-	height := b.end.y - b.start.y
+	posX, posY := b.Position()
+	xDisp := x - posX
+	yDisp := y - posY
 
-	newBounding := newBounding(newPoint(x, y), newPoint(x+width, y+height))
-	*b = *newBounding
+	b.Move(xDisp, yDisp)
 }
 
 func (b *bounding) Position() (x, y float64) {
 
+	if b.tf != nil {
+		return b.tf.anchor.x, b.tf.anchor.y
+	}
+
 	return b.start.x, b.start.y
 }
 
+func (b *bounding) getBounds() *bounding {
+
+	return b
+}
+
+// getBoundsValues returns the corners of the bounding box of the Collider.
+// Unlike the getBounds method, it does not create a new bounding box for
+// shapes that do not keep one, so it is safe to use while testing for
+// collisions.
+func getBoundsValues(collider Collider) (minX, minY, maxX, maxY float64) {
+
+	switch collider := collider.(type) {
+	case *point:
+		return collider.x, collider.y, collider.x, collider.y
+	case *circle:
+		return collider.center.x - collider.radius, collider.center.y - collider.radius,
+			collider.center.x + collider.radius, collider.center.y + collider.radius
+	case *capsule:
+		return math.Min(collider.start.x, collider.end.x) - collider.radius,
+			math.Min(collider.start.y, collider.end.y) - collider.radius,
+			math.Max(collider.start.x, collider.end.x) + collider.radius,
+			math.Max(collider.start.y, collider.end.y) + collider.radius
+	case *compound:
+		minX, minY, maxX, maxY = math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+		for _, val := range collider.children {
+			childMinX, childMinY, childMaxX, childMaxY := getBoundsValues(val)
+			minX, minY = math.Min(minX, childMinX), math.Min(minY, childMinY)
+			maxX, maxY = math.Max(maxX, childMaxX), math.Max(maxY, childMaxY)
+		}
+		return minX, minY, maxX, maxY
+	default:
+		bounds := collider.getBounds()
+		return bounds.start.x, bounds.start.y, bounds.end.x, bounds.end.y
+	}
+}
+
 func (b *bounding) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
 
+	if b.oriented != nil {
+		return b.oriented.DistanceToTangentPoint(x, y, side)
+	}
+
 	switch side {
 	case Up:
 		sideX := x
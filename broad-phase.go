@@ -0,0 +1,385 @@
+package paunch
+
+import (
+	"math"
+)
+
+// BroadPhase corresponds to a strategy an EventManager uses to quickly rule
+// out objects that cannot possibly be colliding.
+type BroadPhase int
+
+// BroadPhase IDs
+const (
+	_ BroadPhase = iota
+	// AABBTree keeps Colliders in a tree of bounding boxes. It needs no
+	// tuning and handles objects of very different sizes well.
+	AABBTree
+	// SpatialHash sorts Colliders into a uniform grid of cells. It is fast
+	// when most objects are close to the cell size.
+	SpatialHash
+	// BruteForce tests every Collider against every other Collider.
+	BruteForce
+)
+
+const (
+	defaultCellSize = 64.0
+	aabbTreeMargin  = 2.0
+)
+
+// proxy is an entry of a broad phase index representing a single Collider
+// belonging to an EventManager object.
+type proxy struct {
+	object        interface{}
+	objectIndex   int
+	collider      Collider
+	colliderIndex int
+	static        bool
+
+	minX, minY, maxX, maxY float64
+
+	// Spatial hash bookkeeping
+	cellMinX, cellMinY, cellMaxX, cellMaxY int
+	queryStamp                             int
+
+	// AABB tree bookkeeping
+	node *aabbNode
+}
+
+func newProxy(object interface{}, collider Collider, colliderIndex int, static bool) *proxy {
+
+	p := &proxy{object: object, collider: collider, colliderIndex: colliderIndex, static: static}
+	p.refreshBounds()
+
+	return p
+}
+
+// refreshBounds updates the proxy's bounding box from its Collider and
+// reports whether it changed.
+func (p *proxy) refreshBounds() bool {
+
+	bounds := p.collider.getBounds()
+	if bounds.start.x == p.minX && bounds.start.y == p.minY &&
+		bounds.end.x == p.maxX && bounds.end.y == p.maxY {
+		return false
+	}
+
+	p.minX, p.minY = bounds.start.x, bounds.start.y
+	p.maxX, p.maxY = bounds.end.x, bounds.end.y
+
+	return true
+}
+
+// broadPhaseIndex is a structure that can quickly find proxies whose bounding
+// boxes overlap a given area.
+type broadPhaseIndex interface {
+	insert(p *proxy)
+	remove(p *proxy)
+	update(p *proxy)
+	query(minX, minY, maxX, maxY float64, callback func(*proxy))
+}
+
+func newBroadPhaseIndex(strategy BroadPhase, cellSize float64) broadPhaseIndex {
+
+	switch strategy {
+	case SpatialHash:
+		return newSpatialHash(cellSize)
+	case BruteForce:
+		return &bruteForceIndex{}
+	default:
+		return &aabbTree{}
+	}
+}
+
+// bruteForceIndex is a broadPhaseIndex that reports every proxy for every
+// query.
+type bruteForceIndex struct {
+	proxies []*proxy
+}
+
+func (index *bruteForceIndex) insert(p *proxy) {
+
+	index.proxies = append(index.proxies, p)
+}
+
+func (index *bruteForceIndex) remove(p *proxy) {
+
+	for i, val := range index.proxies {
+		if val == p {
+			index.proxies = append(index.proxies[:i], index.proxies[i+1:]...)
+			return
+		}
+	}
+}
+
+func (index *bruteForceIndex) update(p *proxy) {
+}
+
+func (index *bruteForceIndex) query(minX, minY, maxX, maxY float64, callback func(*proxy)) {
+
+	for _, val := range index.proxies {
+		callback(val)
+	}
+}
+
+// spatialHash is a broadPhaseIndex that sorts proxies into a uniform grid of
+// cells.
+type spatialHash struct {
+	cellSize float64
+	cells    map[[2]int][]*proxy
+	stamp    int
+}
+
+func newSpatialHash(cellSize float64) *spatialHash {
+
+	if cellSize <= 0 {
+		cellSize = defaultCellSize
+	}
+
+	return &spatialHash{cellSize: cellSize, cells: make(map[[2]int][]*proxy)}
+}
+
+func (hash *spatialHash) cellRange(minX, minY, maxX, maxY float64) (int, int, int, int) {
+
+	return int(math.Floor(minX / hash.cellSize)), int(math.Floor(minY / hash.cellSize)),
+		int(math.Floor(maxX / hash.cellSize)), int(math.Floor(maxY / hash.cellSize))
+}
+
+func (hash *spatialHash) insert(p *proxy) {
+
+	p.cellMinX, p.cellMinY, p.cellMaxX, p.cellMaxY = hash.cellRange(p.minX, p.minY, p.maxX, p.maxY)
+
+	for x := p.cellMinX; x <= p.cellMaxX; x++ {
+		for y := p.cellMinY; y <= p.cellMaxY; y++ {
+			key := [2]int{x, y}
+			hash.cells[key] = append(hash.cells[key], p)
+		}
+	}
+}
+
+func (hash *spatialHash) remove(p *proxy) {
+
+	for x := p.cellMinX; x <= p.cellMaxX; x++ {
+		for y := p.cellMinY; y <= p.cellMaxY; y++ {
+			key := [2]int{x, y}
+			cell := hash.cells[key]
+			for i, val := range cell {
+				if val == p {
+					cell[i] = cell[len(cell)-1]
+					cell = cell[:len(cell)-1]
+					break
+				}
+			}
+			if len(cell) == 0 {
+				delete(hash.cells, key)
+			} else {
+				hash.cells[key] = cell
+			}
+		}
+	}
+}
+
+func (hash *spatialHash) update(p *proxy) {
+
+	minX, minY, maxX, maxY := hash.cellRange(p.minX, p.minY, p.maxX, p.maxY)
+	if minX == p.cellMinX && minY == p.cellMinY && maxX == p.cellMaxX && maxY == p.cellMaxY {
+		return
+	}
+
+	hash.remove(p)
+	hash.insert(p)
+}
+
+func (hash *spatialHash) query(minX, minY, maxX, maxY float64, callback func(*proxy)) {
+
+	hash.stamp++
+
+	check := func(cell []*proxy) {
+		for _, val := range cell {
+			if val.queryStamp == hash.stamp {
+				continue
+			}
+			val.queryStamp = hash.stamp
+
+			if val.minX <= maxX && val.maxX >= minX && val.minY <= maxY && val.maxY >= minY {
+				callback(val)
+			}
+		}
+	}
+
+	// Large or unbounded queries are faster to answer by visiting every
+	// occupied cell
+	spanX := math.Floor(maxX/hash.cellSize) - math.Floor(minX/hash.cellSize) + 1
+	spanY := math.Floor(maxY/hash.cellSize) - math.Floor(minY/hash.cellSize) + 1
+	if !(spanX*spanY <= float64(len(hash.cells))) {
+		for _, cell := range hash.cells {
+			check(cell)
+		}
+		return
+	}
+
+	cellMinX, cellMinY, cellMaxX, cellMaxY := hash.cellRange(minX, minY, maxX, maxY)
+	for x := cellMinX; x <= cellMaxX; x++ {
+		for y := cellMinY; y <= cellMaxY; y++ {
+			check(hash.cells[[2]int{x, y}])
+		}
+	}
+}
+
+// aabbNode is a node of an aabbTree. Leaves hold a single proxy, while branches
+// hold exactly two children.
+type aabbNode struct {
+	minX, minY, maxX, maxY float64
+
+	parent *aabbNode
+	left   *aabbNode
+	right  *aabbNode
+	proxy  *proxy
+}
+
+func (node *aabbNode) isLeaf() bool {
+
+	return node.proxy != nil
+}
+
+func (node *aabbNode) perimeter() float64 {
+
+	return 2 * ((node.maxX - node.minX) + (node.maxY - node.minY))
+}
+
+func (node *aabbNode) contains(minX, minY, maxX, maxY float64) bool {
+
+	return node.minX <= minX && node.minY <= minY && node.maxX >= maxX && node.maxY >= maxY
+}
+
+func (node *aabbNode) refit() {
+
+	node.minX = math.Min(node.left.minX, node.right.minX)
+	node.minY = math.Min(node.left.minY, node.right.minY)
+	node.maxX = math.Max(node.left.maxX, node.right.maxX)
+	node.maxY = math.Max(node.left.maxY, node.right.maxY)
+}
+
+func unionPerimeter(node *aabbNode, minX, minY, maxX, maxY float64) float64 {
+
+	return 2 * ((math.Max(node.maxX, maxX) - math.Min(node.minX, minX)) +
+		(math.Max(node.maxY, maxY) - math.Min(node.minY, minY)))
+}
+
+// aabbTree is a broadPhaseIndex that keeps proxies in a dynamic bounding
+// volume hierarchy. Leaves are slightly enlarged so that proxies that move a
+// small distance do not need to be reinserted.
+type aabbTree struct {
+	root *aabbNode
+}
+
+func (tree *aabbTree) insert(p *proxy) {
+
+	leaf := &aabbNode{minX: p.minX - aabbTreeMargin, minY: p.minY - aabbTreeMargin,
+		maxX: p.maxX + aabbTreeMargin, maxY: p.maxY + aabbTreeMargin, proxy: p}
+	p.node = leaf
+
+	if tree.root == nil {
+		tree.root = leaf
+		return
+	}
+
+	// Find the best sibling by descending toward the child whose bounds
+	// grow the least
+	sibling := tree.root
+	for !sibling.isLeaf() {
+		leftCost := unionPerimeter(sibling.left, leaf.minX, leaf.minY, leaf.maxX, leaf.maxY) - sibling.left.perimeter()
+		rightCost := unionPerimeter(sibling.right, leaf.minX, leaf.minY, leaf.maxX, leaf.maxY) - sibling.right.perimeter()
+		if leftCost <= rightCost {
+			sibling = sibling.left
+		} else {
+			sibling = sibling.right
+		}
+	}
+
+	branch := &aabbNode{parent: sibling.parent, left: sibling, right: leaf}
+	if sibling.parent == nil {
+		tree.root = branch
+	} else if sibling.parent.left == sibling {
+		sibling.parent.left = branch
+	} else {
+		sibling.parent.right = branch
+	}
+	sibling.parent = branch
+	leaf.parent = branch
+
+	for node := branch; node != nil; node = node.parent {
+		node.refit()
+	}
+}
+
+func (tree *aabbTree) remove(p *proxy) {
+
+	leaf := p.node
+	p.node = nil
+	if leaf == nil {
+		return
+	}
+
+	if leaf == tree.root {
+		tree.root = nil
+		return
+	}
+
+	branch := leaf.parent
+	sibling := branch.left
+	if sibling == leaf {
+		sibling = branch.right
+	}
+
+	sibling.parent = branch.parent
+	if branch.parent == nil {
+		tree.root = sibling
+		return
+	}
+
+	if branch.parent.left == branch {
+		branch.parent.left = sibling
+	} else {
+		branch.parent.right = sibling
+	}
+
+	for node := sibling.parent; node != nil; node = node.parent {
+		node.refit()
+	}
+}
+
+func (tree *aabbTree) update(p *proxy) {
+
+	if p.node != nil && p.node.contains(p.minX, p.minY, p.maxX, p.maxY) {
+		return
+	}
+
+	tree.remove(p)
+	tree.insert(p)
+}
+
+func (tree *aabbTree) query(minX, minY, maxX, maxY float64, callback func(*proxy)) {
+
+	if tree.root == nil {
+		return
+	}
+
+	stack := []*aabbNode{tree.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if node.minX > maxX || node.maxX < minX || node.minY > maxY || node.maxY < minY {
+			continue
+		}
+
+		if node.isLeaf() {
+			p := node.proxy
+			if p.minX <= maxX && p.maxX >= minX && p.minY <= maxY && p.maxY >= minY {
+				callback(p)
+			}
+		} else {
+			stack = append(stack, node.left, node.right)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package paunch
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// newBroadPhaseTestObjects creates a scattered set of objects of different
+// sizes and shapes. The same seed always creates the same objects.
+func newBroadPhaseTestObjects(seed int64) []*testObject {
+
+	random := rand.New(rand.NewSource(seed))
+
+	objects := make([]*testObject, 60)
+	for i := range objects {
+		x, y := random.Float64()*400, random.Float64()*400
+		size := 2 + random.Float64()*random.Float64()*150
+
+		var colliders []Collider
+		switch i % 5 {
+		case 0:
+			colliders = append(colliders, NewCollider([]float64{x, y}))
+		case 1:
+			colliders = append(colliders, NewCollider([]float64{x, y, x + size, y, x + size, y + size, x, y + size}))
+		case 2:
+			colliders = append(colliders, NewCircleCollider(x, y, size/2))
+		case 3:
+			colliders = append(colliders, NewCollider([]float64{x, y, x + size, y + (size / 3)}),
+				NewCapsuleCollider(x, y, x, y+size, size/8))
+		case 4:
+			colliders = append(colliders, NewCollider([]float64{x, y, x + size, y, x + (size / 2), y + size}))
+		}
+
+		objects[i] = newTestObject(colliders...)
+	}
+
+	return objects
+}
+
+// runBroadPhaseTest moves a set of objects around for a few frames with the
+// given broad phase and returns, for every frame, the index of each object
+// along with the indices of the objects it was told it collided with.
+func runBroadPhaseTest(strategy BroadPhase, cellSize float64) [][]string {
+
+	objects := newBroadPhaseTestObjects(1)
+	random := rand.New(rand.NewSource(2))
+
+	eventManager := NewEventManager()
+	eventManager.SetBroadPhase(strategy)
+	if cellSize > 0 {
+		eventManager.SetCellSize(cellSize)
+	}
+
+	indices := make(map[interface{}]int)
+	for i, val := range objects {
+		eventManager.Objects = append(eventManager.Objects, val)
+		indices[val] = i
+	}
+	for i := 0; i < len(objects); i += 7 {
+		eventManager.SetStatic(objects[i], true)
+	}
+
+	var frames [][]string
+	for frame := 0; frame < 20; frame++ {
+		for _, val := range objects {
+			val.culprits = nil
+		}
+
+		eventManager.RunCollisionEvent()
+
+		var pairs []string
+		for i, val := range objects {
+			for _, culprit := range val.culprits {
+				pairs = append(pairs, fmt.Sprint(i, indices[culprit]))
+			}
+		}
+		frames = append(frames, pairs)
+
+		for i, val := range objects {
+			if i%7 == 0 {
+				continue
+			}
+			x, y := (random.Float64()-0.5)*40, (random.Float64()-0.5)*40
+			for _, collider := range val.colliders {
+				collider.Move(x, y)
+			}
+		}
+
+		// Drop an object and put it back later, to remove it from the index
+		if frame%4 == 1 {
+			eventManager.Objects = eventManager.Objects[1:]
+		} else if frame%4 == 3 {
+			eventManager.Objects = append(eventManager.Objects, objects[(frame/4)%len(objects)])
+		}
+	}
+
+	return frames
+}
+
+func TestBroadPhaseEquivalence(t *testing.T) {
+
+	want := runBroadPhaseTest(BruteForce, 0)
+
+	collisions := 0
+	for _, val := range want {
+		collisions += len(val)
+	}
+	if collisions == 0 {
+		t.Fatal("the objects never collide")
+	}
+
+	tests := []struct {
+		name     string
+		strategy BroadPhase
+		cellSize float64
+	}{
+		{"AABBTree", AABBTree, 0},
+		{"SpatialHash", SpatialHash, 0},
+		{"SpatialHash with small cells", SpatialHash, 8},
+		{"SpatialHash with large cells", SpatialHash, 500},
+	}
+
+	for _, test := range tests {
+		got := runBroadPhaseTest(test.strategy, test.cellSize)
+		for frame := range want {
+			if !reflect.DeepEqual(got[frame], want[frame]) {
+				t.Errorf("%s: frame %d reported the pairs %v, want %v", test.name, frame, got[frame], want[frame])
+			}
+		}
+	}
+}
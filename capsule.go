@@ -0,0 +1,343 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+	"math"
+)
+
+// capsule is an object that represents every point within a radius of a line
+// segment. It is meant to be used through the Collider interface.
+type capsule struct {
+	start  *point
+	end    *point
+	radius float64
+
+	tf *transform
+	collisionFilter
+}
+
+func newCapsule(start, end *point, radius float64) *capsule {
+
+	return &capsule{start: newPoint(start.x, start.y), end: newPoint(end.x, end.y), radius: math.Abs(radius)}
+}
+
+// NewCapsuleCollider creates a new Collider object in the shape of a capsule,
+// made of every point within the radius of the line segment between x1, y1
+// and x2, y2. Capsules slide around corners smoothly, which makes them well
+// suited for characters. Nil is returned if the radius is negative.
+func NewCapsuleCollider(x1, y1, x2, y2, radius float64) Collider {
+
+	if radius < 0 {
+		return nil
+	}
+
+	return newCapsule(newPoint(x1, y1), newPoint(x2, y2), radius)
+}
+
+func (c *capsule) Move(x, y float64) {
+
+	c.start.Move(x, y)
+	c.end.Move(x, y)
+
+	if c.tf != nil {
+		c.tf.anchor.Move(x, y)
+	}
+}
+
+func (c *capsule) SetPosition(x, y float64) {
+
+	posX, posY := c.Position()
+	xDisp := x - posX
+	yDisp := y - posY
+
+	c.Move(xDisp, yDisp)
+}
+
+// Position returns the x, y coordinates of the start of the capsule's line
+// segment, before any rotation around a different origin is applied.
+func (c *capsule) Position() (x, y float64) {
+
+	if c.tf != nil {
+		return c.tf.anchor.x, c.tf.anchor.y
+	}
+
+	return c.start.x, c.start.y
+}
+
+func (c *capsule) getBounds() *bounding {
+
+	return newBounding(newPoint(math.Min(c.start.x, c.end.x)-c.radius, math.Min(c.start.y, c.end.y)-c.radius),
+		newPoint(math.Max(c.start.x, c.end.x)+c.radius, math.Max(c.start.y, c.end.y)+c.radius))
+}
+
+// overlapsBounds checks if the bounding box of the capsule overlaps the
+// given bounding box, without creating a new one.
+func (c *capsule) overlapsBounds(b *bounding) bool {
+
+	return math.Min(c.start.x, c.end.x)-c.radius <= b.end.x && math.Max(c.start.x, c.end.x)+c.radius >= b.start.x &&
+		math.Min(c.start.y, c.end.y)-c.radius <= b.end.y && math.Max(c.start.y, c.end.y)+c.radius >= b.start.y
+}
+
+func (c *capsule) getConvex() *convexShape {
+
+	return &convexShape{points: []point{{x: c.start.x, y: c.start.y}, {x: c.end.x, y: c.end.y}}, radius: c.radius}
+}
+
+// getCapsuleExtent returns the highest, or lowest, v coordinate of a capsule
+// at the given u coordinate. The u coordinate is clamped to the capsule. By
+// swapping the axes, this finds the extent of a capsule along either axis.
+func getCapsuleExtent(u1, v1, u2, v2, radius, u float64, highest bool) (float64, float64) {
+
+	u = math.Max(math.Min(u1, u2)-radius, math.Min(u, math.Max(u1, u2)+radius))
+
+	extent := math.Inf(-1)
+	if !highest {
+		extent = math.Inf(1)
+	}
+	consider := func(v float64) {
+		if (highest && v > extent) || (!highest && v < extent) {
+			extent = v
+		}
+	}
+
+	// The rounded ends
+	for _, val := range [2][2]float64{{u1, v1}, {u2, v2}} {
+		if dist := u - val[0]; math.Abs(dist) <= radius {
+			height := math.Sqrt((radius * radius) - (dist * dist))
+			consider(val[1] + height)
+			consider(val[1] - height)
+		}
+	}
+
+	// The straight sides, which are the segment offset by the radius
+	normalU, normalV, ok := normalizeRay(v1-v2, u2-u1)
+	if ok && u1 != u2 {
+		for _, side := range [2]float64{radius, -radius} {
+			sideU1, sideV1 := u1+(normalU*side), v1+(normalV*side)
+			sideU2, sideV2 := u2+(normalU*side), v2+(normalV*side)
+			if u >= math.Min(sideU1, sideU2) && u <= math.Max(sideU1, sideU2) {
+				consider(sideV1 + ((u-sideU1)/(sideU2-sideU1))*(sideV2-sideV1))
+			}
+		}
+	}
+
+	return u, extent
+}
+
+func (c *capsule) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
+
+	switch side {
+	case Up, Down:
+		sideX, sideY := getCapsuleExtent(c.start.x, c.start.y, c.end.x, c.end.y, c.radius, x, side == Up)
+		return getPointDistance(newPoint(x, y), newPoint(sideX, sideY))
+	case Left, Right:
+		sideY, sideX := getCapsuleExtent(c.start.y, c.start.x, c.end.y, c.end.x, c.radius, y, side == Right)
+		return getPointDistance(newPoint(x, y), newPoint(sideX, sideY))
+	default:
+		return 0, 0
+	}
+}
+
+// containsPoint checks if the coordinates are inside or on the edge of the
+// capsule.
+func (c *capsule) containsPoint(x, y float64) bool {
+
+	closestX, closestY := getSegmentClosestPoint(c.start.x, c.start.y, c.end.x, c.end.y, x, y)
+	xDist, yDist := x-closestX, y-closestY
+
+	return (xDist*xDist)+(yDist*yDist) <= c.radius*c.radius
+}
+
+// reachesSegment checks if the segment between the two points comes within
+// the capsule's radius of its line segment.
+func (c *capsule) reachesSegment(x1, y1, x2, y2 float64) bool {
+
+	return getSegmentDistanceSquared(c.start.x, c.start.y, c.end.x, c.end.y, x1, y1, x2, y2) <= c.radius*c.radius
+}
+
+func (c *capsule) onPoint(p *point) bool {
+
+	return c.containsPoint(p.x, p.y)
+}
+
+func (c *capsule) onBounding(b *bounding) bool {
+
+	if !c.overlapsBounds(b) {
+		return false
+	}
+
+	if b.onPoint(c.start) || b.onPoint(c.end) {
+		return true
+	}
+
+	for i := 0; i < 4; i++ {
+		if c.reachesSegment(b.getEdge(i)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *capsule) onLine(l *line) bool {
+
+	return c.reachesSegment(l.start.x, l.start.y, l.end.x, l.end.y)
+}
+
+func (c *capsule) onPolygon(poly *polygon) bool {
+
+	if !c.overlapsBounds(poly.bounds) {
+		return false
+	}
+
+	if poly.containsPoint(c.start.x, c.start.y) {
+		return true
+	}
+
+	for _, val := range poly.lines {
+		if c.reachesSegment(val.start.x, val.start.y, val.end.x, val.end.y) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *capsule) onCircle(c2 *circle) bool {
+
+	closestX, closestY := getSegmentClosestPoint(c.start.x, c.start.y, c.end.x, c.end.y, c2.center.x, c2.center.y)
+	xDist, yDist := c2.center.x-closestX, c2.center.y-closestY
+	radii := c.radius + c2.radius
+
+	return (xDist*xDist)+(yDist*yDist) <= radii*radii
+}
+
+func (c *capsule) onCapsule(c2 *capsule) bool {
+
+	radii := c.radius + c2.radius
+
+	return getSegmentDistanceSquared(c.start.x, c.start.y, c.end.x, c.end.y,
+		c2.start.x, c2.start.y, c2.end.x, c2.end.y) <= radii*radii
+}
+
+func (c *compound) onCapsule(c2 *capsule) bool {
+
+	return c.collidesWith(c2)
+}
+
+func (c *circle) onCapsule(c2 *capsule) bool {
+
+	return c2.onCircle(c)
+}
+
+func (poly *polygon) onCapsule(c *capsule) bool {
+
+	return c.onPolygon(poly)
+}
+
+func (l *line) onCapsule(c *capsule) bool {
+
+	return c.onLine(l)
+}
+
+func (b *bounding) onCapsule(c *capsule) bool {
+
+	return c.onBounding(b)
+}
+
+func (p *point) onCapsule(c *capsule) bool {
+
+	return c.onPoint(p)
+}
+
+func (c *capsule) getTransform() *transform {
+
+	if c.tf == nil {
+		c.tf = newTransform(c.start.x, c.start.y, []point{
+			{x: c.start.x, y: c.start.y},
+			{x: c.end.x, y: c.end.y}}, c.radius)
+	}
+
+	return c.tf
+}
+
+// applyTransform updates the capsule with its transformed end points and
+// radius.
+func (c *capsule) applyTransform() {
+
+	ends := c.tf.apply()
+	c.start, c.end = newPoint(ends[0].x, ends[0].y), newPoint(ends[1].x, ends[1].y)
+	c.radius = c.tf.radius()
+}
+
+// Rotate rotates the capsule by the specified angle, in radians, around its
+// origin.
+func (c *capsule) Rotate(angle float64) {
+
+	rotateCollider(c, angle)
+}
+
+// SetRotation sets the rotation of the capsule, in radians, around its
+// origin.
+func (c *capsule) SetRotation(angle float64) {
+
+	setColliderRotation(c, angle)
+}
+
+// Rotation returns the rotation of the capsule in radians.
+func (c *capsule) Rotation() float64 {
+
+	if c.tf == nil {
+		return 0
+	}
+
+	return c.tf.rotation
+}
+
+// SetOrigin sets the point, relative to the capsule's position, that rotation
+// and scaling happen around.
+func (c *capsule) SetOrigin(x, y float64) {
+
+	setColliderOrigin(c, x, y)
+}
+
+// SetScale sets the scaling factor of the capsule along the x and y axes. The
+// radius is scaled by the larger of the two values.
+func (c *capsule) SetScale(x, y float64) {
+
+	setColliderScale(c, x, y)
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes the capsule, if within the
+// maximum distance.
+func (c *capsule) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	if c.containsPoint(originX, originY) {
+		return newRaycastHit(c, originX, originY, dirX, dirY, 0, -dirX, -dirY), true
+	}
+
+	hull := []point{{x: c.start.x - originX, y: c.start.y - originY}}
+	if c.start.x != c.end.x || c.start.y != c.end.y {
+		hull = append(hull, point{x: c.end.x - originX, y: c.end.y - originY})
+	}
+
+	t, normalX, normalY, ok := raycastRoundedHull(hull, c.radius, dirX, dirY, maxDistance)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	return newRaycastHit(c, originX, originY, dirX, dirY, t, normalX, normalY), true
+}
+
+// Geometry returns the shape of the capsule as a geometry.Capsule.
+func (c *capsule) Geometry() geometry.Shape {
+
+	return geometry.Capsule{Start: geometry.Vec2{X: c.start.x, Y: c.start.y},
+		End: geometry.Vec2{X: c.end.x, Y: c.end.y}, Radius: c.radius}
+}
@@ -0,0 +1,175 @@
+package paunch
+
+import (
+	"math"
+)
+
+// circle is an object that represents a circle with a center point and a
+// radius. It is meant to be used through the Collider interface.
+type circle struct {
+	center *point
+	radius float64
+
+	tf *transform
+	collisionFilter
+}
+
+func newCircle(center *point, radius float64) *circle {
+
+	return &circle{center: newPoint(center.x, center.y), radius: math.Abs(radius)}
+}
+
+// NewCircleCollider creates a new Collider object in the shape of a circle
+// with the specified center and radius. Circles are a faster and more
+// accurate alternative to many-sided polygons for round objects.
+func NewCircleCollider(x, y, radius float64) Collider {
+
+	if radius < 0 {
+		return nil
+	}
+
+	return newCircle(newPoint(x, y), radius)
+}
+
+func (c *circle) Move(x, y float64) {
+
+	c.center.Move(x, y)
+
+	if c.tf != nil {
+		c.tf.anchor.Move(x, y)
+	}
+}
+
+func (c *circle) SetPosition(x, y float64) {
+
+	posX, posY := c.Position()
+	xDisp := x - posX
+	yDisp := y - posY
+
+	c.Move(xDisp, yDisp)
+}
+
+// Position returns the x, y coordinates of the center of the circle, before
+// any rotation around a different origin is applied.
+func (c *circle) Position() (x, y float64) {
+
+	if c.tf != nil {
+		return c.tf.anchor.x, c.tf.anchor.y
+	}
+
+	return c.center.x, c.center.y
+}
+
+func (c *circle) getBounds() *bounding {
+
+	return newBounding(newPoint(c.center.x-c.radius, c.center.y-c.radius),
+		newPoint(c.center.x+c.radius, c.center.y+c.radius))
+}
+
+func (c *circle) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
+
+	switch side {
+	case Up, Down:
+		sideX := x
+		if x < c.center.x-c.radius {
+			sideX = c.center.x - c.radius
+		} else if x > c.center.x+c.radius {
+			sideX = c.center.x + c.radius
+		}
+		height := math.Sqrt(math.Max(0, (c.radius*c.radius)-((sideX-c.center.x)*(sideX-c.center.x))))
+		if side == Down {
+			height = -height
+		}
+		return getPointDistance(newPoint(x, y), newPoint(sideX, c.center.y+height))
+	case Left, Right:
+		sideY := y
+		if y < c.center.y-c.radius {
+			sideY = c.center.y - c.radius
+		} else if y > c.center.y+c.radius {
+			sideY = c.center.y + c.radius
+		}
+		width := math.Sqrt(math.Max(0, (c.radius*c.radius)-((sideY-c.center.y)*(sideY-c.center.y))))
+		if side == Left {
+			width = -width
+		}
+		return getPointDistance(newPoint(x, y), newPoint(c.center.x+width, sideY))
+	default:
+		return 0, 0
+	}
+}
+
+func (c *circle) onPoint(p *point) bool {
+
+	return c.containsPoint(p.x, p.y)
+}
+
+// containsPoint checks if the coordinates are inside or on the edge of the
+// circle.
+func (c *circle) containsPoint(x, y float64) bool {
+
+	xDist, yDist := x-c.center.x, y-c.center.y
+
+	return (xDist*xDist)+(yDist*yDist) <= c.radius*c.radius
+}
+
+func (c *circle) onBounding(b *bounding) bool {
+
+	return c.containsPoint(math.Max(b.start.x, math.Min(c.center.x, b.end.x)),
+		math.Max(b.start.y, math.Min(c.center.y, b.end.y)))
+}
+
+func (c *circle) onLine(l *line) bool {
+
+	if !c.onBounding(l.bounds) {
+		return false
+	}
+
+	return c.containsPoint(l.getClosestPoint(c.center.x, c.center.y))
+}
+
+func (c *circle) onPolygon(poly *polygon) bool {
+
+	if !c.onBounding(poly.bounds) {
+		return false
+	}
+
+	if poly.onPoint(c.center) {
+		return true
+	}
+
+	for _, val := range poly.lines {
+		if c.onLine(val) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *circle) onCircle(c2 *circle) bool {
+
+	xDist, yDist := getPointDistance(c.center, c2.center)
+	radii := c.radius + c2.radius
+
+	return (xDist*xDist)+(yDist*yDist) <= radii*radii
+}
+
+func (poly *polygon) onCircle(c *circle) bool {
+
+	return c.onPolygon(poly)
+}
+
+func (l *line) onCircle(c *circle) bool {
+
+	return c.onLine(l)
+}
+
+func (b *bounding) onCircle(c *circle) bool {
+
+	return c.onBounding(b)
+}
+
+func (p *point) onCircle(c *circle) bool {
+
+	return c.onPoint(p)
+}
@@ -1,6 +1,7 @@
 package paunch
 
 import (
+	"github.com/velovix/paunch/geometry"
 	"math"
 )
 
@@ -11,6 +12,10 @@ type Collider interface {
 	onBounding(*bounding) bool
 	onLine(*line) bool
 	onPolygon(*polygon) bool
+	onCircle(*circle) bool
+	onCapsule(*capsule) bool
+	getConvex() *convexShape
+	getBounds() *bounding
 
 	// Move moves the Collider object the specified distance.
 	Move(x, y float64)
@@ -23,18 +28,114 @@ type Collider interface {
 	// tangent to the Collider object. This method is useful for position
 	// correction when objects have sunk into each other.
 	DistanceToTangentPoint(float64, float64, Direction) (float64, float64)
+	// Rotate rotates the Collider object by the specified angle, in
+	// radians, around its origin.
+	Rotate(angle float64)
+	// SetRotation sets the rotation of the Collider object, in radians,
+	// around its origin.
+	SetRotation(angle float64)
+	// Rotation returns the rotation of the Collider object in radians.
+	Rotation() float64
+	// SetOrigin sets the point, relative to the Collider object's position,
+	// that rotation and scaling happen around. The default origin is the
+	// Collider object's position.
+	SetOrigin(x, y float64)
+	// SetScale sets the scaling factor of the Collider object along the x
+	// and y axes.
+	SetScale(x, y float64)
+	// SetCollisionCategory sets the categories the Collider object belongs
+	// to as a bit field.
+	SetCollisionCategory(category uint32)
+	// CollisionCategory returns the categories the Collider object belongs
+	// to as a bit field.
+	CollisionCategory() uint32
+	// SetCollisionMask sets the categories the Collider object can collide
+	// with as a bit field.
+	SetCollisionMask(mask uint32)
+	// CollisionMask returns the categories the Collider object can collide
+	// with as a bit field.
+	CollisionMask() uint32
+	// SetSensor sets whether or not the Collider object is a sensor.
+	// Sensors report collisions but are never used to push objects apart.
+	SetSensor(sensor bool)
+	// IsSensor returns true if the Collider object is a sensor.
+	IsSensor() bool
+	// Raycast casts a ray from the origin x, y toward the direction x, y and
+	// returns the nearest point where it strikes the Collider object, if it
+	// does so within the maximum distance. Rays that start inside the
+	// Collider strike it immediately.
+	Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool)
+	// Geometry returns the current shape of the Collider object as a value
+	// from the geometry package.
+	Geometry() geometry.Shape
 }
 
-// NewCollider creates a new Collider object. The supplied coordinates should
-// be in an "x1, y1, x2, y2..." format. Colliders work differently internally
-// depending on the shape the coordinate describes. Collision detection is
-// faster for singular points and bounding boxes than with lines and polygons.
-func NewCollider(coords []float64) Collider {
+// ColliderOption changes how NewCollider creates a Collider.
+type ColliderOption int
+
+// ColliderOption IDs
+const (
+	_ ColliderOption = iota
+	// Decompose splits concave polygons into convex parts, which are grouped
+	// into a single compound Collider. Overlap and resolution math is only
+	// exact for convex shapes.
+	Decompose
+)
+
+// hasColliderOption checks if the option is among the given options.
+func hasColliderOption(options []ColliderOption, option ColliderOption) bool {
+
+	for _, val := range options {
+		if val == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewCollider creates a new Collider object. The shape is either a []float64
+// of coordinates in an "x1, y1, x2, y2..." format, or a value of the geometry
+// package, which is created as described by NewColliderFromGeometry. Colliders
+// work differently internally depending on the shape the coordinates describe.
+// Collision detection is faster for singular points and bounding boxes than
+// with lines and polygons. Nil is returned for any other type of shape.
+func NewCollider(shape interface{}, options ...ColliderOption) Collider {
+
+	decompose := hasColliderOption(options, Decompose)
+
+	var coords []float64
+	switch shape := shape.(type) {
+	case []float64:
+		coords = shape
+	case geometry.Polygon:
+		if decompose && len(shape.Points) > 3 {
+			coords = make([]float64, 0, len(shape.Points)*2)
+			for _, val := range shape.Points {
+				coords = append(coords, val.X, val.Y)
+			}
+			if parts := NewConvexColliders(coords); len(parts) > 1 {
+				return newCompound(coords[0], coords[1], parts)
+			}
+		}
+		return NewColliderFromGeometry(shape)
+	case geometry.Shape:
+		return NewColliderFromGeometry(shape)
+	default:
+		return nil
+	}
 
 	if len(coords) == 0 || len(coords)%2 != 0 {
 		return nil
 	}
 
+	if decompose && len(coords) > 6 {
+		parts := NewConvexColliders(coords)
+		if len(parts) > 1 {
+			return newCompound(coords[0], coords[1], parts)
+		}
+	}
+
 	if len(coords) == 2 {
 		return newPoint(coords[0], coords[1])
 	}
@@ -83,9 +184,12 @@ func NewCollider(coords []float64) Collider {
 	return newPolygon(points)
 }
 
-// Collides checks if two Collider-satisfying objects are overlapping.
+// Collides checks if two Collider-satisfying objects are overlapping. The
+// collision categories and masks of the Colliders are not taken into account.
 func Collides(collider1, collider2 Collider) bool {
 
+	collider1, collider2 = resolveCollider(collider1), resolveCollider(collider2)
+
 	switch collider2.(type) {
 	case *point:
 		return collider1.onPoint(collider2.(*point))
@@ -95,7 +199,24 @@ func Collides(collider1, collider2 Collider) bool {
 		return collider1.onLine(collider2.(*line))
 	case *polygon:
 		return collider1.onPolygon(collider2.(*polygon))
+	case *circle:
+		return collider1.onCircle(collider2.(*circle))
+	case *capsule:
+		return collider1.onCapsule(collider2.(*capsule))
+	case group:
+		return collider2.(group).collidesWith(collider1)
 	default:
 		return false
 	}
 }
+
+// resolveCollider returns the Collider that actually describes the shape of
+// the given Collider. Rotated bounding boxes are described by a polygon.
+func resolveCollider(collider Collider) Collider {
+
+	if b, ok := collider.(*bounding); ok && b.oriented != nil {
+		return b.oriented
+	}
+
+	return collider
+}
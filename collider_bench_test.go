@@ -0,0 +1,457 @@
+package paunch
+
+import (
+	"testing"
+)
+
+// benchmarkColliders creates the overlapping Colliders that are checked
+// against each other by the Collides benchmarks.
+var benchmarkColliders = map[string]func() Collider{
+	"Point":    func() Collider { return NewCollider([]float64{5, 5}) },
+	"Bounding": func() Collider { return NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}) },
+	"Line":     func() Collider { return NewCollider([]float64{0, 0, 10, 10}) },
+	"Polygon":  func() Collider { return NewCollider([]float64{0, 0, 10, 0, 12, 8, 5, 12, -2, 8}) },
+	"Circle":   func() Collider { return NewCircleCollider(5, 5, 3) },
+	"Capsule":  func() Collider { return NewCapsuleCollider(0, 5, 10, 5, 2) },
+	"Compound": func() Collider {
+		return NewCompoundCollider(0, 0,
+			NewCollider([]float64{0, 0, 4, 0, 4, 4, 0, 4}),
+			NewCollider([]float64{4, 4, 10, 4, 4, 10}))
+	},
+	"TileGrid": func() Collider {
+		return NewTileGridCollider(0, 0, 2, 2, [][]bool{
+			{true, true, true, true, true},
+			{true, false, false, false, true},
+			{true, false, true, false, true},
+			{true, false, false, false, true},
+			{true, true, true, true, true}})
+	},
+	"Mask": func() Collider {
+		// A 10x10 image with a transparent hole near a corner
+		data := make([]byte, 10*10*4)
+		for i := 3; i < len(data); i += 4 {
+			if row, column := (i/4)/10, (i/4)%10; row < 2 || row > 3 || column < 2 || column > 3 {
+				data[i] = 255
+			}
+		}
+		return NewMaskCollider(0, 0, 10, 10, data, 128)
+	},
+}
+
+func benchmarkCollides(b *testing.B, name1, name2 string) {
+
+	collider1 := benchmarkColliders[name1]()
+	collider2 := benchmarkColliders[name2]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		Collides(collider1, collider2)
+	}
+}
+
+func BenchmarkCollides_Point_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Point")
+}
+
+func BenchmarkCollides_Point_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Bounding")
+}
+
+func BenchmarkCollides_Point_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Line")
+}
+
+func BenchmarkCollides_Point_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Polygon")
+}
+
+func BenchmarkCollides_Point_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Circle")
+}
+
+func BenchmarkCollides_Point_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Capsule")
+}
+
+func BenchmarkCollides_Point_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Compound")
+}
+
+func BenchmarkCollides_Point_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "TileGrid")
+}
+
+func BenchmarkCollides_Point_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Point", "Mask")
+}
+
+func BenchmarkCollides_Bounding_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Point")
+}
+
+func BenchmarkCollides_Bounding_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Bounding")
+}
+
+func BenchmarkCollides_Bounding_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Line")
+}
+
+func BenchmarkCollides_Bounding_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Polygon")
+}
+
+func BenchmarkCollides_Bounding_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Circle")
+}
+
+func BenchmarkCollides_Bounding_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Capsule")
+}
+
+func BenchmarkCollides_Bounding_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Compound")
+}
+
+func BenchmarkCollides_Bounding_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "TileGrid")
+}
+
+func BenchmarkCollides_Bounding_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Bounding", "Mask")
+}
+
+func BenchmarkCollides_Line_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Point")
+}
+
+func BenchmarkCollides_Line_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Bounding")
+}
+
+func BenchmarkCollides_Line_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Line")
+}
+
+func BenchmarkCollides_Line_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Polygon")
+}
+
+func BenchmarkCollides_Line_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Circle")
+}
+
+func BenchmarkCollides_Line_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Capsule")
+}
+
+func BenchmarkCollides_Line_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Compound")
+}
+
+func BenchmarkCollides_Line_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "TileGrid")
+}
+
+func BenchmarkCollides_Line_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Line", "Mask")
+}
+
+func BenchmarkCollides_Polygon_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Point")
+}
+
+func BenchmarkCollides_Polygon_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Bounding")
+}
+
+func BenchmarkCollides_Polygon_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Line")
+}
+
+func BenchmarkCollides_Polygon_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Polygon")
+}
+
+func BenchmarkCollides_Polygon_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Circle")
+}
+
+func BenchmarkCollides_Polygon_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Capsule")
+}
+
+func BenchmarkCollides_Polygon_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Compound")
+}
+
+func BenchmarkCollides_Polygon_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "TileGrid")
+}
+
+func BenchmarkCollides_Polygon_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Polygon", "Mask")
+}
+
+func BenchmarkCollides_Circle_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Point")
+}
+
+func BenchmarkCollides_Circle_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Bounding")
+}
+
+func BenchmarkCollides_Circle_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Line")
+}
+
+func BenchmarkCollides_Circle_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Polygon")
+}
+
+func BenchmarkCollides_Circle_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Circle")
+}
+
+func BenchmarkCollides_Circle_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Capsule")
+}
+
+func BenchmarkCollides_Circle_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Compound")
+}
+
+func BenchmarkCollides_Circle_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "TileGrid")
+}
+
+func BenchmarkCollides_Circle_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Circle", "Mask")
+}
+
+func BenchmarkCollides_Capsule_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Point")
+}
+
+func BenchmarkCollides_Capsule_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Bounding")
+}
+
+func BenchmarkCollides_Capsule_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Line")
+}
+
+func BenchmarkCollides_Capsule_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Polygon")
+}
+
+func BenchmarkCollides_Capsule_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Circle")
+}
+
+func BenchmarkCollides_Capsule_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Capsule")
+}
+
+func BenchmarkCollides_Capsule_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Compound")
+}
+
+func BenchmarkCollides_Capsule_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "TileGrid")
+}
+
+func BenchmarkCollides_Capsule_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Capsule", "Mask")
+}
+
+func BenchmarkCollides_Compound_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Point")
+}
+
+func BenchmarkCollides_Compound_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Bounding")
+}
+
+func BenchmarkCollides_Compound_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Line")
+}
+
+func BenchmarkCollides_Compound_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Polygon")
+}
+
+func BenchmarkCollides_Compound_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Circle")
+}
+
+func BenchmarkCollides_Compound_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Capsule")
+}
+
+func BenchmarkCollides_Compound_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Compound")
+}
+
+func BenchmarkCollides_Compound_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "TileGrid")
+}
+
+func BenchmarkCollides_Compound_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Compound", "Mask")
+}
+
+func BenchmarkCollides_TileGrid_Point(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Point")
+}
+
+func BenchmarkCollides_TileGrid_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Bounding")
+}
+
+func BenchmarkCollides_TileGrid_Line(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Line")
+}
+
+func BenchmarkCollides_TileGrid_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Polygon")
+}
+
+func BenchmarkCollides_TileGrid_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Circle")
+}
+
+func BenchmarkCollides_TileGrid_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Capsule")
+}
+
+func BenchmarkCollides_TileGrid_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Compound")
+}
+
+func BenchmarkCollides_TileGrid_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "TileGrid")
+}
+
+func BenchmarkCollides_TileGrid_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "TileGrid", "Mask")
+}
+
+func BenchmarkCollides_Mask_Point(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Point")
+}
+
+func BenchmarkCollides_Mask_Bounding(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Bounding")
+}
+
+func BenchmarkCollides_Mask_Line(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Line")
+}
+
+func BenchmarkCollides_Mask_Polygon(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Polygon")
+}
+
+func BenchmarkCollides_Mask_Circle(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Circle")
+}
+
+func BenchmarkCollides_Mask_Capsule(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Capsule")
+}
+
+func BenchmarkCollides_Mask_Compound(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Compound")
+}
+
+func BenchmarkCollides_Mask_TileGrid(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "TileGrid")
+}
+
+func BenchmarkCollides_Mask_Mask(b *testing.B) {
+
+	benchmarkCollides(b, "Mask", "Mask")
+}
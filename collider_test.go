@@ -0,0 +1,144 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+	"reflect"
+	"testing"
+)
+
+func TestCollides(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		coords1 []float64
+		coords2 []float64
+		want    bool
+	}{
+		// Points
+		{"point on point", []float64{1, 1}, []float64{1, 1}, true},
+		{"point off point", []float64{1, 1}, []float64{1, 2}, false},
+		{"point in bounding", []float64{5, 5}, []float64{0, 0, 10, 0, 10, 10, 0, 10}, true},
+		{"point on bounding edge", []float64{10, 5}, []float64{0, 0, 10, 0, 10, 10, 0, 10}, true},
+		{"point outside bounding", []float64{11, 5}, []float64{0, 0, 10, 0, 10, 10, 0, 10}, false},
+		{"point on line", []float64{5, 5}, []float64{0, 0, 10, 10}, true},
+		{"point on line end", []float64{10, 10}, []float64{0, 0, 10, 10}, true},
+		{"point beside line", []float64{5, 6}, []float64{0, 0, 10, 10}, false},
+		{"point past line end", []float64{11, 11}, []float64{0, 0, 10, 10}, false},
+		{"point in polygon", []float64{3, 3}, []float64{0, 0, 10, 0, 0, 10}, true},
+		{"point on polygon vertex", []float64{10, 0}, []float64{0, 0, 10, 0, 0, 10}, true},
+		{"point outside polygon", []float64{6, 6}, []float64{0, 0, 10, 0, 0, 10}, false},
+
+		// Boundings
+		{"bounding contains bounding", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{2, 2, 4, 2, 4, 4, 2, 4}, true},
+		{"bounding overlaps bounding", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{5, 5, 15, 5, 15, 15, 5, 15}, true},
+		{"bounding touches bounding edge", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{10, 0, 20, 0, 20, 10, 10, 10}, true},
+		{"bounding touches bounding corner", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{10, 10, 20, 10, 20, 20, 10, 20}, true},
+		{"bounding apart from bounding", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{11, 0, 20, 0, 20, 10, 11, 10}, false},
+		{"bounding contains line", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{2, 2, 8, 3}, true},
+		{"bounding crossed by line", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{-5, 5, 15, 5}, true},
+		{"bounding edge overlapped by line", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{5, 10, 15, 10}, true},
+		{"bounding touched by line end", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{10, 10, 15, 15}, true},
+		{"bounding apart from line", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{11, 0, 11, 10}, false},
+		{"bounding contains polygon", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{2, 2, 5, 2, 2, 5}, true},
+		{"bounding inside polygon", []float64{4, 4, 6, 4, 6, 6, 4, 6},
+			[]float64{0, 0, 20, 0, 10, 20}, true},
+		{"bounding touches polygon vertex", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{10, 10, 20, 10, 20, 20}, true},
+		{"bounding apart from polygon", []float64{0, 0, 10, 0, 10, 10, 0, 10},
+			[]float64{20, 0, 30, 0, 20, 10}, false},
+
+		// Lines
+		{"line crosses line", []float64{0, 0, 10, 10}, []float64{0, 10, 10, 0}, true},
+		{"line touches line end", []float64{0, 0, 10, 0}, []float64{10, 0, 10, 10}, true},
+		{"line apart from line", []float64{0, 0, 10, 0}, []float64{0, 1, 10, 1}, false},
+		{"line meets line extension", []float64{0, 0, 10, 0}, []float64{11, -5, 11, 5}, false},
+		{"horizontal lines overlap", []float64{0, 0, 10, 0}, []float64{5, 0, 15, 0}, true},
+		{"horizontal line contains line", []float64{0, 0, 10, 0}, []float64{2, 0, 8, 0}, true},
+		{"horizontal lines touch", []float64{0, 0, 10, 0}, []float64{10, 0, 15, 0}, true},
+		{"horizontal lines apart", []float64{0, 0, 10, 0}, []float64{11, 0, 15, 0}, false},
+		{"vertical lines overlap", []float64{3, 0, 3, 10}, []float64{3, 5, 3, 15}, true},
+		{"vertical line contains line", []float64{3, 0, 3, 10}, []float64{3, 8, 3, 2}, true},
+		{"vertical lines touch", []float64{3, 0, 3, 10}, []float64{3, 10, 3, 15}, true},
+		{"vertical lines apart", []float64{3, 0, 3, 10}, []float64{3, 11, 3, 15}, false},
+		{"diagonal lines overlap", []float64{0, 0, 10, 10}, []float64{5, 5, 15, 15}, true},
+		{"diagonal line contains line", []float64{0, 0, 10, 10}, []float64{8, 8, 2, 2}, true},
+		{"diagonal lines touch", []float64{0, 0, 10, 10}, []float64{10, 10, 15, 15}, true},
+		{"diagonal lines apart", []float64{0, 0, 10, 10}, []float64{11, 11, 15, 15}, false},
+		{"parallel diagonal lines", []float64{0, 0, 10, 10}, []float64{0, 1, 10, 11}, false},
+		{"line inside polygon", []float64{2, 2, 4, 3}, []float64{0, 0, 10, 0, 0, 10}, true},
+		{"line crosses polygon", []float64{-5, 2, 15, 2}, []float64{0, 0, 10, 0, 0, 10}, true},
+		{"line along polygon edge", []float64{2, 0, 8, 0}, []float64{0, 0, 10, 0, 0, 10}, true},
+		{"line touches polygon vertex", []float64{0, 10, 0, 15}, []float64{0, 0, 10, 0, 0, 10}, true},
+		{"line apart from polygon", []float64{6, 6, 10, 10}, []float64{0, 0, 10, 0, 0, 10}, false},
+
+		// Polygons
+		{"polygon contains polygon", []float64{0, 0, 20, 0, 10, 20},
+			[]float64{8, 4, 12, 4, 10, 8}, true},
+		{"polygon overlaps polygon", []float64{0, 0, 10, 0, 0, 10},
+			[]float64{2, 2, 12, 2, 2, 12}, true},
+		{"polygon shares polygon edge", []float64{0, 0, 10, 0, 0, 10},
+			[]float64{10, 0, 10, 10, 0, 10}, true},
+		{"polygon touches polygon vertex", []float64{0, 0, 10, 0, 0, 10},
+			[]float64{10, 0, 20, 0, 20, 10}, true},
+		{"polygon apart from polygon", []float64{0, 0, 10, 0, 0, 10},
+			[]float64{6, 6, 16, 6, 6, 16}, false},
+	}
+
+	for _, test := range tests {
+		collider1 := NewCollider(test.coords1)
+		collider2 := NewCollider(test.coords2)
+
+		if got := Collides(collider1, collider2); got != test.want {
+			t.Errorf("%s: Collides(%T, %T) = %v, want %v", test.name, collider1, collider2, got, test.want)
+		}
+		if got := Collides(collider2, collider1); got != test.want {
+			t.Errorf("%s: Collides(%T, %T) = %v, want %v", test.name, collider2, collider1, got, test.want)
+		}
+	}
+}
+
+func TestNewColliderGeometry(t *testing.T) {
+
+	shapes := []geometry.Shape{
+		geometry.Vec2{X: 1, Y: 2},
+		geometry.Rect{Min: geometry.Vec2{X: 0, Y: 0}, Max: geometry.Vec2{X: 4, Y: 2}},
+		geometry.Segment{Start: geometry.Vec2{X: 0, Y: 0}, End: geometry.Vec2{X: 4, Y: 2}},
+		geometry.Polygon{Points: []geometry.Vec2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 2}}},
+		geometry.Circle{Center: geometry.Vec2{X: 1, Y: 1}, Radius: 2},
+		geometry.Capsule{Start: geometry.Vec2{X: 0, Y: 0}, End: geometry.Vec2{X: 4, Y: 0}, Radius: 1},
+	}
+
+	for _, val := range shapes {
+		collider := NewCollider(val)
+		if collider == nil {
+			t.Errorf("NewCollider(%#v) = nil", val)
+			continue
+		}
+		if got := collider.Geometry(); !reflect.DeepEqual(got, val) {
+			t.Errorf("NewCollider(%#v).Geometry() = %#v", val, got)
+		}
+	}
+
+	concave := geometry.Polygon{Points: []geometry.Vec2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 2, Y: 1}, {X: 0, Y: 4}}}
+	if _, ok := NewCollider(concave, Decompose).(*compound); !ok {
+		t.Error("NewCollider did not decompose a concave geometry.Polygon")
+	}
+	if _, ok := NewCollider(concave).(*polygon); !ok {
+		t.Error("NewCollider decomposed a geometry.Polygon without the Decompose option")
+	}
+
+	if collider := NewCollider("square"); collider != nil {
+		t.Errorf("NewCollider(\"square\") = %T, want nil", collider)
+	}
+}
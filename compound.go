@@ -0,0 +1,381 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+	"math"
+)
+
+// compound is an object that groups several Colliders so that they move and
+// collide as one. It is meant to be used through the Collider interface.
+type compound struct {
+	children []Collider
+	x, y     float64
+
+	rotation         float64
+	originX, originY float64
+	collisionFilter
+}
+
+func newCompound(x, y float64, children []Collider) *compound {
+
+	return &compound{children: children, x: x, y: y}
+}
+
+// NewCompoundCollider creates a new Collider object that groups the supplied
+// Colliders, which are positioned relative to the given x, y coordinates.
+// The children move, rotate and scale together, and a collision with any of
+// them is a collision with the compound Collider. CollidingChildren reports
+// which children were involved in a collision. Nil is returned if no
+// children are supplied or if any of them are nil.
+func NewCompoundCollider(x, y float64, children ...Collider) Collider {
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	for _, val := range children {
+		if val == nil {
+			return nil
+		}
+		val.Move(x, y)
+	}
+
+	return newCompound(x, y, children)
+}
+
+// group is a Collider that is made up of other Colliders, like a compound
+// Collider.
+type group interface {
+	Collider
+	// getChildren returns the Colliders of the group that may overlap the
+	// given bounds, or every Collider of the group if the bounds are nil.
+	getChildren(bounds *bounding) []Collider
+	// collidesWith checks if any Collider of the group overlaps the given
+	// Collider.
+	collidesWith(collider Collider) bool
+}
+
+// isGroup checks if either of the given Colliders is made up of other
+// Colliders.
+func isGroup(collider1, collider2 Collider) bool {
+
+	_, group1 := collider1.(group)
+	_, group2 := collider2.(group)
+
+	return group1 || group2
+}
+
+// getLeaves returns the Colliders that make up the given Collider and may
+// overlap the given bounds, expanding compound Colliders into their children.
+// Other groups are only expanded if all is true, since their children are not
+// visible to users. Nil bounds include every Collider.
+func getLeaves(collider Collider, bounds *bounding, all bool) []Collider {
+
+	g, ok := collider.(group)
+	if _, isCompound := collider.(*compound); !ok || (!all && !isCompound) {
+		return []Collider{collider}
+	}
+
+	var leaves []Collider
+	for _, val := range g.getChildren(bounds) {
+		leaves = append(leaves, getLeaves(val, bounds, all)...)
+	}
+
+	return leaves
+}
+
+// getCollidingChildren returns every pair of non-compound Colliders, taken
+// from the two given Colliders, that are overlapping. Groups other than
+// compound Colliders are only expanded if all is true.
+func getCollidingChildren(collider1, collider2 Collider, all bool) [][2]Collider {
+
+	if !isGroup(collider1, collider2) {
+		if Collides(collider1, collider2) {
+			return [][2]Collider{{collider1, collider2}}
+		}
+		return nil
+	}
+
+	bounds1, bounds2 := collider1.getBounds(), collider2.getBounds()
+	if !bounds1.onBounding(bounds2) {
+		return nil
+	}
+
+	var pairs [][2]Collider
+	for _, val1 := range getLeaves(collider1, bounds2, all) {
+		for _, val2 := range getLeaves(collider2, bounds1, all) {
+			if Collides(val1, val2) {
+				pairs = append(pairs, [2]Collider{val1, val2})
+			}
+		}
+	}
+
+	return pairs
+}
+
+// CollidingChildren checks if two Collider-satisfying objects are overlapping
+// and, if they are, returns the first pair of overlapping Colliders that
+// are not compound Colliders. Colliders that are not compound are returned
+// as they are.
+func CollidingChildren(collider1, collider2 Collider) (Collider, Collider, bool) {
+
+	pairs := getCollidingChildren(collider1, collider2, false)
+	if len(pairs) == 0 {
+		return nil, nil, false
+	}
+
+	return pairs[0][0], pairs[0][1], true
+}
+
+func (c *compound) getChildren(bounds *bounding) []Collider {
+
+	if bounds == nil {
+		return c.children
+	}
+
+	var children []Collider
+	for _, val := range c.children {
+		if val.getBounds().onBounding(bounds) {
+			children = append(children, val)
+		}
+	}
+
+	return children
+}
+
+func (c *compound) collidesWith(collider Collider) bool {
+
+	for _, val := range c.children {
+		if Collides(val, collider) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *compound) onPoint(p *point) bool {
+
+	return c.collidesWith(p)
+}
+
+func (c *compound) onBounding(b *bounding) bool {
+
+	return c.collidesWith(b)
+}
+
+func (c *compound) onLine(l *line) bool {
+
+	return c.collidesWith(l)
+}
+
+func (c *compound) onPolygon(poly *polygon) bool {
+
+	return c.collidesWith(poly)
+}
+
+func (c *compound) onCircle(c2 *circle) bool {
+
+	return c.collidesWith(c2)
+}
+
+// getConvex returns the convex hull of the compound's children. Round
+// children contribute the corners of their bounds.
+func (c *compound) getConvex() *convexShape {
+
+	var points []point
+	for _, val := range c.children {
+		shape := val.getConvex()
+		if shape.radius == 0 {
+			points = append(points, shape.points...)
+			continue
+		}
+
+		bounds := val.getBounds()
+		points = append(points, point{x: bounds.start.x, y: bounds.start.y},
+			point{x: bounds.end.x, y: bounds.start.y},
+			point{x: bounds.end.x, y: bounds.end.y},
+			point{x: bounds.start.x, y: bounds.end.y})
+	}
+
+	return &convexShape{points: getConvexHull(points)}
+}
+
+func (c *compound) getBounds() *bounding {
+
+	start := point{x: math.Inf(1), y: math.Inf(1)}
+	end := point{x: math.Inf(-1), y: math.Inf(-1)}
+
+	for _, val := range c.children {
+		bounds := val.getBounds()
+		start.x = math.Min(start.x, bounds.start.x)
+		start.y = math.Min(start.y, bounds.start.y)
+		end.x = math.Max(end.x, bounds.end.x)
+		end.y = math.Max(end.y, bounds.end.y)
+	}
+
+	return &bounding{start: &start, end: &end}
+}
+
+func (c *compound) Move(x, y float64) {
+
+	for _, val := range c.children {
+		val.Move(x, y)
+	}
+
+	c.x += x
+	c.y += y
+}
+
+func (c *compound) SetPosition(x, y float64) {
+
+	posX, posY := c.Position()
+	xDisp := x - posX
+	yDisp := y - posY
+
+	c.Move(xDisp, yDisp)
+}
+
+// Position returns the x, y coordinates that the compound's children are
+// positioned relative to.
+func (c *compound) Position() (x, y float64) {
+
+	return c.x, c.y
+}
+
+// DistanceToTangentPoint returns the distance to the tangent point of the
+// child that is farthest toward the specified side. Children that are
+// directly in line with the coordinates are preferred.
+func (c *compound) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
+
+	var bestX, bestY float64
+	found, foundInLine := false, false
+
+	for _, val := range c.children {
+		xDist, yDist := val.DistanceToTangentPoint(x, y, side)
+
+		bounds := val.getBounds()
+		inLine := false
+		switch side {
+		case Up, Down:
+			inLine = x >= bounds.start.x && x <= bounds.end.x
+		case Left, Right:
+			inLine = y >= bounds.start.y && y <= bounds.end.y
+		}
+		if foundInLine && !inLine {
+			continue
+		}
+
+		better := !found || (inLine && !foundInLine)
+		switch side {
+		case Up:
+			better = better || yDist > bestY
+		case Down:
+			better = better || yDist < bestY
+		case Left:
+			better = better || xDist < bestX
+		case Right:
+			better = better || xDist > bestX
+		}
+
+		if better {
+			bestX, bestY = xDist, yDist
+			found = true
+			foundInLine = inLine
+		}
+	}
+
+	return bestX, bestY
+}
+
+// alignChildren sets the origin of each child to the origin of the compound,
+// so that the children rotate and scale together. The children keep their
+// current shape, even if they have already been rotated or scaled around
+// another origin.
+func (c *compound) alignChildren() {
+
+	for _, val := range c.children {
+		x, y := val.Position()
+		if t, ok := val.(transformer); ok {
+			rebaseColliderOrigin(t, c.x+c.originX-x, c.y+c.originY-y)
+		} else {
+			val.SetOrigin(c.x+c.originX-x, c.y+c.originY-y)
+		}
+	}
+}
+
+// Rotate rotates the compound's children by the specified angle, in radians,
+// around the compound's origin.
+func (c *compound) Rotate(angle float64) {
+
+	c.alignChildren()
+	for _, val := range c.children {
+		val.Rotate(angle)
+	}
+
+	c.rotation += angle
+}
+
+// SetRotation sets the rotation of the compound, in radians, around its
+// origin.
+func (c *compound) SetRotation(angle float64) {
+
+	c.Rotate(angle - c.rotation)
+}
+
+// Rotation returns the rotation of the compound in radians.
+func (c *compound) Rotation() float64 {
+
+	return c.rotation
+}
+
+// SetOrigin sets the point, relative to the compound's position, that rotation
+// and scaling happen around. The children stay where they are, so a rotated
+// compound keeps its current shape and only later rotation and scaling happen
+// around the new origin.
+func (c *compound) SetOrigin(x, y float64) {
+
+	c.originX, c.originY = x, y
+	c.alignChildren()
+}
+
+// SetScale sets the scaling factor of each of the compound's children along
+// the x and y axes, around the compound's origin.
+func (c *compound) SetScale(x, y float64) {
+
+	c.alignChildren()
+	for _, val := range c.children {
+		val.SetScale(x, y)
+	}
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes one of the compound's
+// children, if within the maximum distance. The Collider of the RaycastHit
+// is the child that was struck.
+func (c *compound) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	var first RaycastHit
+	hit := false
+	for _, val := range c.children {
+		if childHit, ok := val.Raycast(originX, originY, dirX, dirY, maxDistance); ok &&
+			(!hit || childHit.Distance < first.Distance) {
+			first = childHit
+			hit = true
+		}
+	}
+
+	return first, hit
+}
+
+// Geometry returns the shape of the compound as a geometry.Group holding the
+// shape of each child.
+func (c *compound) Geometry() geometry.Shape {
+
+	shapes := make([]geometry.Shape, len(c.children))
+	for i, val := range c.children {
+		shapes[i] = val.Geometry()
+	}
+
+	return geometry.Group{Shapes: shapes}
+}
@@ -0,0 +1,46 @@
+package paunch
+
+import (
+	"math"
+	"testing"
+)
+
+func checkBounds(t *testing.T, name string, bounds *bounding, minX, minY, maxX, maxY float64) {
+
+	if math.Abs(bounds.start.x-minX) > tolerance || math.Abs(bounds.start.y-minY) > tolerance ||
+		math.Abs(bounds.end.x-maxX) > tolerance || math.Abs(bounds.end.y-maxY) > tolerance {
+		t.Errorf("%s: bounds are %v, %v to %v, %v, want %v, %v to %v, %v", name,
+			bounds.start.x, bounds.start.y, bounds.end.x, bounds.end.y, minX, minY, maxX, maxY)
+	}
+}
+
+func TestCompoundSetOriginAfterRotation(t *testing.T) {
+
+	c := NewCompoundCollider(0, 0,
+		NewCollider([]float64{0, 0, 2, 0, 2, 1, 0, 1}),
+		NewCircleCollider(1, 0.5, 0.5))
+
+	c.Rotate(math.Pi / 2)
+	checkBounds(t, "rotated", c.getBounds(), -1, 0, 0, 2)
+
+	c.SetOrigin(10, 0)
+	checkBounds(t, "origin moved", c.getBounds(), -1, 0, 0, 2)
+
+	c.Rotate(math.Pi / 2)
+	checkBounds(t, "rotated around the new origin", c.getBounds(), 8, -11, 10, -10)
+
+	c.SetRotation(0)
+	checkBounds(t, "rotation reset", c.getBounds(), 10, 10, 12, 11)
+}
+
+func TestCompoundGetBounds(t *testing.T) {
+
+	c := NewCompoundCollider(0, 0, NewCollider([]float64{0, 0, 2, 0, 2, 1, 0, 1}))
+
+	bounds := c.getBounds()
+	c.Move(5, 5)
+	if other := c.getBounds(); other == bounds {
+		t.Error("getBounds returned the same bounding box twice")
+	}
+	checkBounds(t, "before moving", bounds, 0, 0, 2, 1)
+}
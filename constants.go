@@ -8,6 +8,10 @@ import (
 
 const tolerance = 0.01
 
+// epsilon is the amount of floating point error allowed in exact geometric
+// calculations.
+const epsilon = 1e-9
+
 // Axis corresponds to an axis value.
 type Axis int
 
@@ -0,0 +1,32 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+)
+
+// NewConvexColliders splits the polygon described by the supplied
+// coordinates, which should be in an "x1, y1, x2, y2..." format, into convex
+// polygon Colliders. The polygon must not intersect itself. Convex polygons
+// produce a single Collider. Nil is returned if the coordinates do not
+// describe a polygon.
+func NewConvexColliders(coords []float64) []Collider {
+
+	if len(coords) < 6 || len(coords)%2 != 0 {
+		return nil
+	}
+
+	var poly geometry.Polygon
+	for i := 0; i < len(coords); i += 2 {
+		poly.Points = append(poly.Points, geometry.Vec2{X: coords[i], Y: coords[i+1]})
+	}
+
+	var colliders []Collider
+	for _, val := range poly.Decompose() {
+		if len(val.Points) < 3 {
+			continue
+		}
+		colliders = append(colliders, NewColliderFromGeometry(val))
+	}
+
+	return colliders
+}
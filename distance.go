@@ -0,0 +1,95 @@
+package paunch
+
+import (
+	"math"
+)
+
+// ClosestPoint returns the point on the Collider-satisfying object that is
+// closest to the given coordinates. Coordinates that are already inside the
+// Collider are returned unchanged.
+func ClosestPoint(collider Collider, x, y float64) (float64, float64) {
+
+	if Collides(collider, newPoint(x, y)) {
+		return x, y
+	}
+
+	if g, ok := collider.(group); ok {
+		var closestX, closestY float64
+		closestDist := math.Inf(1)
+		for _, val := range g.getChildren(nil) {
+			childX, childY := ClosestPoint(val, x, y)
+			if dist := math.Hypot(childX-x, childY-y); dist < closestDist {
+				closestX, closestY, closestDist = childX, childY, dist
+			}
+		}
+		return closestX, closestY
+	}
+
+	shape := collider.getConvex()
+	closest := shape.closestPoint(x, y)
+	if shape.radius == 0 {
+		return closest.x, closest.y
+	}
+
+	dirX, dirY, ok := normalizeRay(x-closest.x, y-closest.y)
+	if !ok {
+		return closest.x, closest.y
+	}
+
+	return closest.x + (dirX * shape.radius), closest.y + (dirY * shape.radius)
+}
+
+// Distance returns the shortest distance between two Collider-satisfying
+// objects, along with the closest point on each of them. Colliders that touch
+// or overlap have a distance of zero, and both points are set to a point
+// where they meet.
+func Distance(collider1, collider2 Collider) (distance, x1, y1, x2, y2 float64) {
+
+	if manifold, ok := CollisionManifold(collider1, collider2); ok {
+		contact := manifold.Contacts[0]
+		return 0, contact.X, contact.Y, contact.X, contact.Y
+	}
+
+	if isGroup(collider1, collider2) {
+		distance = math.Inf(1)
+		for _, val1 := range getLeaves(collider1, nil, true) {
+			for _, val2 := range getLeaves(collider2, nil, true) {
+				if dist, childX1, childY1, childX2, childY2 := Distance(val1, val2); dist < distance {
+					distance, x1, y1, x2, y2 = dist, childX1, childY1, childX2, childY2
+				}
+			}
+		}
+		return distance, x1, y1, x2, y2
+	}
+
+	shape1, shape2 := collider1.getConvex(), collider2.getConvex()
+
+	// Since the Colliders do not overlap, the closest points always include
+	// a vertex of one of the shapes
+	closestDist := math.Inf(1)
+	for _, val := range shape1.points {
+		candidate := shape2.closestPoint(val.x, val.y)
+		xDist, yDist := getPointDistance(&val, &candidate)
+		if dist := math.Hypot(xDist, yDist); dist < closestDist {
+			closestDist = dist
+			x1, y1, x2, y2 = val.x, val.y, candidate.x, candidate.y
+		}
+	}
+	for _, val := range shape2.points {
+		candidate := shape1.closestPoint(val.x, val.y)
+		xDist, yDist := getPointDistance(&candidate, &val)
+		if dist := math.Hypot(xDist, yDist); dist < closestDist {
+			closestDist = dist
+			x1, y1, x2, y2 = candidate.x, candidate.y, val.x, val.y
+		}
+	}
+
+	// Round shapes extend their outline toward each other by their radius
+	dirX, dirY, ok := normalizeRay(x2-x1, y2-y1)
+	if ok {
+		x1, y1 = x1+(dirX*shape1.radius), y1+(dirY*shape1.radius)
+		x2, y2 = x2-(dirX*shape2.radius), y2-(dirY*shape2.radius)
+	}
+
+	return math.Max(0, closestDist-shape1.radius-shape2.radius), x1, y1, x2, y2
+}
@@ -0,0 +1,755 @@
+package paunch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/velovix/paunch/geometry"
+	"io"
+	"math"
+)
+
+type colliderType byte
+
+const (
+	_ colliderType = iota
+	pointColliderType
+	rectColliderType
+	lineColliderType
+	polygonColliderType
+	circleColliderType
+	capsuleColliderType
+	compoundColliderType
+	tileGridColliderType
+)
+
+// colliderTypeNames are the type tags used by the JSON form of Colliders.
+var colliderTypeNames = map[colliderType]string{
+	pointColliderType:    "point",
+	rectColliderType:     "rect",
+	lineColliderType:     "line",
+	polygonColliderType:  "polygon",
+	circleColliderType:   "circle",
+	capsuleColliderType:  "capsule",
+	compoundColliderType: "compound",
+	tileGridColliderType: "tileGrid",
+}
+
+// colliderBinaryVersion is the first byte of the binary form of a Collider,
+// so that the format can change without breaking old data.
+const colliderBinaryVersion byte = 1
+
+// colliderData is the serialized form of a Collider. Shapes are stored as
+// they currently are, with any rotation and scaling already applied, rather
+// than as an untransformed shape and its rotation, origin and scale.
+type colliderData struct {
+	Type   string       `json:"type"`
+	Points [][2]float64 `json:"points,omitempty"`
+	Radius float64      `json:"radius,omitempty"`
+
+	// X and Y are the position of compound Colliders and tile grids.
+	X          float64 `json:"x,omitempty"`
+	Y          float64 `json:"y,omitempty"`
+	TileWidth  float64 `json:"tileWidth,omitempty"`
+	TileHeight float64 `json:"tileHeight,omitempty"`
+	// Tiles holds a string for every row of a tile grid, starting with the
+	// bottom row, where '#' is a solid tile and '.' is an empty one.
+	Tiles    []string       `json:"tiles,omitempty"`
+	Children []colliderData `json:"children,omitempty"`
+
+	Category uint32  `json:"category,omitempty"`
+	Mask     *uint32 `json:"mask,omitempty"`
+	Sensor   bool    `json:"sensor,omitempty"`
+}
+
+func vec2Points(points ...geometry.Vec2) [][2]float64 {
+
+	data := make([][2]float64, len(points))
+	for i, val := range points {
+		data[i] = [2]float64{val.X, val.Y}
+	}
+
+	return data
+}
+
+// newColliderData returns the serialized form of the given Collider.
+func newColliderData(collider Collider) (colliderData, error) {
+
+	var data colliderData
+
+	switch collider := collider.(type) {
+	case *compound:
+		data.Type = colliderTypeNames[compoundColliderType]
+		data.X, data.Y = collider.Position()
+		data.Children = make([]colliderData, len(collider.children))
+		for i, val := range collider.children {
+			child, err := newColliderData(val)
+			if err != nil {
+				return colliderData{}, err
+			}
+			data.Children[i] = child
+		}
+	case *tileGrid:
+		data.Type = colliderTypeNames[tileGridColliderType]
+		data.X, data.Y = collider.Position()
+		data.TileWidth, data.TileHeight = collider.tileWidth, collider.tileHeight
+		data.Tiles = make([]string, len(collider.solid))
+		for i, row := range collider.solid {
+			tiles := make([]byte, len(row))
+			for j, solid := range row {
+				tiles[j] = '.'
+				if solid {
+					tiles[j] = '#'
+				}
+			}
+			data.Tiles[i] = string(tiles)
+		}
+	default:
+		switch shape := collider.Geometry().(type) {
+		case geometry.Vec2:
+			data.Type = colliderTypeNames[pointColliderType]
+			data.Points = vec2Points(shape)
+		case geometry.Rect:
+			data.Type = colliderTypeNames[rectColliderType]
+			data.Points = vec2Points(shape.Min, shape.Max)
+		case geometry.Segment:
+			data.Type = colliderTypeNames[lineColliderType]
+			data.Points = vec2Points(shape.Start, shape.End)
+		case geometry.Polygon:
+			data.Type = colliderTypeNames[polygonColliderType]
+			data.Points = vec2Points(shape.Points...)
+		case geometry.Circle:
+			data.Type = colliderTypeNames[circleColliderType]
+			data.Points = vec2Points(shape.Center)
+			data.Radius = shape.Radius
+		case geometry.Capsule:
+			data.Type = colliderTypeNames[capsuleColliderType]
+			data.Points = vec2Points(shape.Start, shape.End)
+			data.Radius = shape.Radius
+		default:
+			return colliderData{}, fmt.Errorf("unsupported collider type %T", collider)
+		}
+	}
+
+	if category := collider.CollisionCategory(); category != DefaultCollisionCategory {
+		data.Category = category
+	}
+	if mask := collider.CollisionMask(); mask != math.MaxUint32 {
+		data.Mask = &mask
+	}
+	data.Sensor = collider.IsSensor()
+
+	return data, nil
+}
+
+// getColliderType returns the type of Collider with the given JSON type tag.
+func getColliderType(name string) (colliderType, bool) {
+
+	for key, val := range colliderTypeNames {
+		if val == name {
+			return key, true
+		}
+	}
+
+	return 0, false
+}
+
+// pointCounts are the number of points each type of Collider is stored with,
+// where zero means any number of points.
+var pointCounts = map[colliderType]int{
+	pointColliderType:   1,
+	rectColliderType:    2,
+	lineColliderType:    2,
+	circleColliderType:  1,
+	capsuleColliderType: 2,
+}
+
+// newColliderFromData creates the Collider described by the serialized form.
+func newColliderFromData(data colliderData) (Collider, error) {
+
+	typ, ok := getColliderType(data.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown collider type %q", data.Type)
+	}
+
+	if count, ok := pointCounts[typ]; ok && len(data.Points) != count {
+		return nil, fmt.Errorf("%s collider has %d points, expected %d", data.Type, len(data.Points), count)
+	}
+	points := make([]geometry.Vec2, len(data.Points))
+	for i, val := range data.Points {
+		points[i] = geometry.Vec2{X: val[0], Y: val[1]}
+	}
+
+	var collider Collider
+	switch typ {
+	case pointColliderType:
+		collider = NewColliderFromGeometry(points[0])
+	case rectColliderType:
+		collider = NewColliderFromGeometry(geometry.Rect{Min: points[0], Max: points[1]})
+	case lineColliderType:
+		collider = NewColliderFromGeometry(geometry.Segment{Start: points[0], End: points[1]})
+	case polygonColliderType:
+		if len(points) < 3 {
+			return nil, fmt.Errorf("polygon collider has %d points, expected at least 3", len(points))
+		}
+		collider = NewColliderFromGeometry(geometry.Polygon{Points: points})
+	case circleColliderType:
+		collider = NewColliderFromGeometry(geometry.Circle{Center: points[0], Radius: data.Radius})
+	case capsuleColliderType:
+		collider = NewColliderFromGeometry(geometry.Capsule{Start: points[0], End: points[1], Radius: data.Radius})
+	case compoundColliderType:
+		if len(data.Children) == 0 {
+			return nil, errors.New("compound collider has no children")
+		}
+		children := make([]Collider, len(data.Children))
+		for i, val := range data.Children {
+			child, err := newColliderFromData(val)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = child
+		}
+		collider = newCompound(data.X, data.Y, children)
+	case tileGridColliderType:
+		solid := make([][]bool, len(data.Tiles))
+		for i, row := range data.Tiles {
+			solid[i] = make([]bool, len(row))
+			for j := 0; j < len(row); j++ {
+				switch row[j] {
+				case '#':
+					solid[i][j] = true
+				case '.':
+				default:
+					return nil, fmt.Errorf("tile grid collider has unknown tile %q", row[j])
+				}
+			}
+		}
+		collider = NewTileGridCollider(data.X, data.Y, data.TileWidth, data.TileHeight, solid)
+	}
+
+	if collider == nil {
+		return nil, fmt.Errorf("invalid %s collider", data.Type)
+	}
+
+	collider.SetCollisionCategory(data.Category)
+	if data.Mask != nil {
+		collider.SetCollisionMask(*data.Mask)
+	}
+	collider.SetSensor(data.Sensor)
+
+	return collider, nil
+}
+
+// MarshalCollider returns the JSON form of the Collider. Every form has a
+// "type" field naming the kind of Collider, which is one of "point", "rect",
+// "line", "polygon", "circle", "capsule", "compound" or "tileGrid". Shapes are
+// stored as they currently are, so a rotated bounding box is stored as a
+// polygon, and collision categories, masks and sensor flags are kept. The
+// rotation, origin and scale of the Collider are not stored separately: they
+// are baked into the stored shape, so the Collider that is decoded has the
+// same shape, but a rotation of zero, a scale of one and its origin at its
+// position.
+func MarshalCollider(collider Collider) ([]byte, error) {
+
+	if collider == nil {
+		return nil, errors.New("collider is nil")
+	}
+
+	data, err := newColliderData(collider)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalCollider creates a Collider from its JSON form, as returned by
+// MarshalCollider. Shapes are described by a list of [x, y] "points": one for
+// points, the minimum and maximum corners for rects, the ends for lines, every
+// corner for polygons, the center for circles and the ends for capsules.
+// Circles and capsules also have a "radius". Compound Colliders have a list
+// of "children", positioned absolutely, and tile grids have a "tileWidth",
+// "tileHeight" and a string of '#' and '.' tiles for each of their "tiles"
+// rows, starting with the bottom row. Compound Colliders and tile grids are
+// positioned at "x" and "y".
+func UnmarshalCollider(data []byte) (Collider, error) {
+
+	var parsed colliderData
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	return newColliderFromData(parsed)
+}
+
+// MarshalColliderBinary returns a compact binary form of the Collider, which
+// holds the same information as the JSON form returned by MarshalCollider.
+func MarshalColliderBinary(collider Collider) ([]byte, error) {
+
+	if collider == nil {
+		return nil, errors.New("collider is nil")
+	}
+
+	data, err := newColliderData(collider)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(colliderBinaryVersion)
+	writeColliderData(&buf, data)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalColliderBinary creates a Collider from its binary form, as
+// returned by MarshalColliderBinary.
+func UnmarshalColliderBinary(data []byte) (Collider, error) {
+
+	reader := bytes.NewReader(data)
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != colliderBinaryVersion {
+		return nil, fmt.Errorf("unsupported collider format version %d", version)
+	}
+
+	parsed, err := readColliderData(reader)
+	if err != nil {
+		return nil, err
+	}
+	if reader.Len() != 0 {
+		return nil, errors.New("unexpected data after collider")
+	}
+
+	return newColliderFromData(parsed)
+}
+
+// Flags stored in the binary form of a Collider.
+const (
+	sensorFlag byte = 1 << iota
+	categoryFlag
+	maskFlag
+)
+
+// writeColliderData writes the binary form of a Collider. Every value is
+// little-endian.
+func writeColliderData(buf *bytes.Buffer, data colliderData) {
+
+	typ, _ := getColliderType(data.Type)
+	buf.WriteByte(byte(typ))
+
+	var flags byte
+	if data.Sensor {
+		flags |= sensorFlag
+	}
+	if data.Category != 0 {
+		flags |= categoryFlag
+	}
+	if data.Mask != nil {
+		flags |= maskFlag
+	}
+	buf.WriteByte(flags)
+	if data.Category != 0 {
+		binary.Write(buf, binary.LittleEndian, data.Category)
+	}
+	if data.Mask != nil {
+		binary.Write(buf, binary.LittleEndian, *data.Mask)
+	}
+
+	switch typ {
+	case compoundColliderType:
+		binary.Write(buf, binary.LittleEndian, [2]float64{data.X, data.Y})
+		binary.Write(buf, binary.LittleEndian, uint32(len(data.Children)))
+		for _, val := range data.Children {
+			writeColliderData(buf, val)
+		}
+	case tileGridColliderType:
+		binary.Write(buf, binary.LittleEndian, [4]float64{data.X, data.Y, data.TileWidth, data.TileHeight})
+		binary.Write(buf, binary.LittleEndian, uint32(len(data.Tiles)))
+		for _, row := range data.Tiles {
+			// Tiles are packed eight to a byte
+			packed := make([]byte, (len(row)+7)/8)
+			for i := 0; i < len(row); i++ {
+				if row[i] == '#' {
+					packed[i/8] |= 1 << uint(i%8)
+				}
+			}
+			binary.Write(buf, binary.LittleEndian, uint32(len(row)))
+			buf.Write(packed)
+		}
+	default:
+		if _, ok := pointCounts[typ]; !ok {
+			binary.Write(buf, binary.LittleEndian, uint32(len(data.Points)))
+		}
+		binary.Write(buf, binary.LittleEndian, data.Points)
+		if typ == circleColliderType || typ == capsuleColliderType {
+			binary.Write(buf, binary.LittleEndian, data.Radius)
+		}
+	}
+}
+
+// readCount reads a count of items that are each at least size bytes long,
+// making sure that the rest of the data is long enough to hold them.
+func readCount(reader *bytes.Reader, size int) (int, error) {
+
+	var count uint32
+	if err := binary.Read(reader, binary.LittleEndian, &count); err != nil {
+		return 0, err
+	}
+	if uint64(count)*uint64(size) > uint64(reader.Len()) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	return int(count), nil
+}
+
+// readColliderData reads the binary form of a Collider, as written by
+// writeColliderData.
+func readColliderData(reader *bytes.Reader) (colliderData, error) {
+
+	var data colliderData
+
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return data, err
+	}
+	typ, flags := colliderType(header[0]), header[1]
+	name, ok := colliderTypeNames[typ]
+	if !ok {
+		return data, fmt.Errorf("unknown collider type %d", typ)
+	}
+	data.Type = name
+
+	data.Sensor = flags&sensorFlag != 0
+	if flags&categoryFlag != 0 {
+		if err := binary.Read(reader, binary.LittleEndian, &data.Category); err != nil {
+			return data, err
+		}
+	}
+	if flags&maskFlag != 0 {
+		data.Mask = new(uint32)
+		if err := binary.Read(reader, binary.LittleEndian, data.Mask); err != nil {
+			return data, err
+		}
+	}
+
+	switch typ {
+	case compoundColliderType:
+		var position [2]float64
+		if err := binary.Read(reader, binary.LittleEndian, &position); err != nil {
+			return data, err
+		}
+		data.X, data.Y = position[0], position[1]
+
+		count, err := readCount(reader, 2)
+		if err != nil {
+			return data, err
+		}
+		data.Children = make([]colliderData, count)
+		for i := range data.Children {
+			if data.Children[i], err = readColliderData(reader); err != nil {
+				return data, err
+			}
+		}
+	case tileGridColliderType:
+		var values [4]float64
+		if err := binary.Read(reader, binary.LittleEndian, &values); err != nil {
+			return data, err
+		}
+		data.X, data.Y, data.TileWidth, data.TileHeight = values[0], values[1], values[2], values[3]
+
+		count, err := readCount(reader, 4)
+		if err != nil {
+			return data, err
+		}
+		data.Tiles = make([]string, count)
+		for i := range data.Tiles {
+			var length uint32
+			if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+				return data, err
+			}
+			if (uint64(length)+7)/8 > uint64(reader.Len()) {
+				return data, io.ErrUnexpectedEOF
+			}
+			packed := make([]byte, (length+7)/8)
+			if _, err := io.ReadFull(reader, packed); err != nil {
+				return data, err
+			}
+
+			row := make([]byte, length)
+			for j := range row {
+				row[j] = '.'
+				if packed[j/8]&(1<<uint(j%8)) != 0 {
+					row[j] = '#'
+				}
+			}
+			data.Tiles[i] = string(row)
+		}
+	default:
+		count, ok := pointCounts[typ]
+		if !ok {
+			var err error
+			if count, err = readCount(reader, 16); err != nil {
+				return data, err
+			}
+		}
+		data.Points = make([][2]float64, count)
+		if err := binary.Read(reader, binary.LittleEndian, data.Points); err != nil {
+			return data, err
+		}
+		if typ == circleColliderType || typ == capsuleColliderType {
+			if err := binary.Read(reader, binary.LittleEndian, &data.Radius); err != nil {
+				return data, err
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// unmarshalColliderAs creates a Collider from its JSON form, as described by
+// UnmarshalCollider, returning an error if the form is for a different type
+// of Collider.
+func unmarshalColliderAs(data []byte, typ colliderType) (Collider, error) {
+
+	var parsed colliderData
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	if parsed.Type != colliderTypeNames[typ] {
+		return nil, fmt.Errorf("cannot unmarshal %s collider into %s collider", parsed.Type, colliderTypeNames[typ])
+	}
+
+	return newColliderFromData(parsed)
+}
+
+// MarshalJSON returns the JSON form of the point, as described by
+// MarshalCollider.
+func (p *point) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(p)
+}
+
+// UnmarshalJSON replaces the point with the one described by its JSON form,
+// as described by UnmarshalCollider. An error is returned if the form is not
+// for a point.
+func (p *point) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, pointColliderType)
+	if err != nil {
+		return err
+	}
+	*p = *collider.(*point)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON form of the bounding box, as described by
+// MarshalCollider.
+func (b *bounding) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(b)
+}
+
+// UnmarshalJSON replaces the bounding box with the one described by its JSON
+// form, as described by UnmarshalCollider. An error is returned if the form
+// is not for a bounding box. A rotated bounding box is encoded as a polygon,
+// so it can only be decoded with UnmarshalCollider or a ColliderValue.
+func (b *bounding) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, rectColliderType)
+	if err != nil {
+		return err
+	}
+	*b = *collider.(*bounding)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON form of the line, as described by
+// MarshalCollider.
+func (l *line) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(l)
+}
+
+// UnmarshalJSON replaces the line with the one described by its JSON form,
+// as described by UnmarshalCollider. An error is returned if the form is not
+// for a line.
+func (l *line) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, lineColliderType)
+	if err != nil {
+		return err
+	}
+	*l = *collider.(*line)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON form of the polygon, as described by
+// MarshalCollider.
+func (poly *polygon) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(poly)
+}
+
+// UnmarshalJSON replaces the polygon with the one described by its JSON form,
+// as described by UnmarshalCollider. An error is returned if the form is not
+// for a polygon.
+func (poly *polygon) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, polygonColliderType)
+	if err != nil {
+		return err
+	}
+	*poly = *collider.(*polygon)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON form of the circle, as described by
+// MarshalCollider.
+func (c *circle) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(c)
+}
+
+// UnmarshalJSON replaces the circle with the one described by its JSON form,
+// as described by UnmarshalCollider. An error is returned if the form is not
+// for a circle.
+func (c *circle) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, circleColliderType)
+	if err != nil {
+		return err
+	}
+	*c = *collider.(*circle)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON form of the capsule, as described by
+// MarshalCollider.
+func (c *capsule) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(c)
+}
+
+// UnmarshalJSON replaces the capsule with the one described by its JSON form,
+// as described by UnmarshalCollider. An error is returned if the form is not
+// for a capsule.
+func (c *capsule) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, capsuleColliderType)
+	if err != nil {
+		return err
+	}
+	*c = *collider.(*capsule)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON form of the compound Collider, as described by
+// MarshalCollider.
+func (c *compound) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(c)
+}
+
+// UnmarshalJSON replaces the compound Collider with the one described by its
+// JSON form, as described by UnmarshalCollider. An error is returned if the
+// form is not for a compound Collider.
+func (c *compound) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, compoundColliderType)
+	if err != nil {
+		return err
+	}
+	*c = *collider.(*compound)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON form of the tile grid, as described by
+// MarshalCollider.
+func (grid *tileGrid) MarshalJSON() ([]byte, error) {
+
+	return MarshalCollider(grid)
+}
+
+// UnmarshalJSON replaces the tile grid with the one described by its JSON form,
+// as described by UnmarshalCollider. An error is returned if the form is not
+// for a tile grid.
+func (grid *tileGrid) UnmarshalJSON(data []byte) error {
+
+	collider, err := unmarshalColliderAs(data, tileGridColliderType)
+	if err != nil {
+		return err
+	}
+	*grid = *collider.(*tileGrid)
+
+	return nil
+}
+
+// ColliderValue holds a Collider so that it can be declared as a field of a
+// larger structure that is encoded as JSON or in binary, like a level or an
+// entity definition. A nil Collider is encoded as JSON null.
+type ColliderValue struct {
+	Collider Collider
+}
+
+// MarshalJSON returns the JSON form of the Collider, as described by
+// MarshalCollider.
+func (value ColliderValue) MarshalJSON() ([]byte, error) {
+
+	if value.Collider == nil {
+		return []byte("null"), nil
+	}
+
+	return MarshalCollider(value.Collider)
+}
+
+// UnmarshalJSON creates the Collider from its JSON form, as described by
+// UnmarshalCollider.
+func (value *ColliderValue) UnmarshalJSON(data []byte) error {
+
+	if string(bytes.TrimSpace(data)) == "null" {
+		value.Collider = nil
+		return nil
+	}
+
+	collider, err := UnmarshalCollider(data)
+	if err != nil {
+		return err
+	}
+	value.Collider = collider
+
+	return nil
+}
+
+// MarshalBinary returns the binary form of the Collider, as described by
+// MarshalColliderBinary.
+func (value ColliderValue) MarshalBinary() ([]byte, error) {
+
+	return MarshalColliderBinary(value.Collider)
+}
+
+// UnmarshalBinary creates the Collider from its binary form, as described by
+// UnmarshalColliderBinary.
+func (value *ColliderValue) UnmarshalBinary(data []byte) error {
+
+	collider, err := UnmarshalColliderBinary(data)
+	if err != nil {
+		return err
+	}
+	value.Collider = collider
+
+	return nil
+}
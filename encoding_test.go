@@ -0,0 +1,134 @@
+package paunch
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestColliderJSON(t *testing.T) {
+
+	colliders := []Collider{
+		NewCollider([]float64{1, 2}),
+		NewCollider([]float64{0, 0, 10, 0, 10, 5, 0, 5}),
+		NewCollider([]float64{0, 0, 10, 10}),
+		NewCollider([]float64{0, 0, 10, 0, 5, 10}),
+		NewCircleCollider(3, 4, 2),
+		NewCapsuleCollider(0, 0, 10, 0, 1),
+		NewCompoundCollider(0, 0, NewCollider([]float64{0, 0, 10, 0, 10, 5, 0, 5}), NewCircleCollider(3, 4, 2)),
+		NewTileGridCollider(0, 0, 2, 2, [][]bool{{true, false}, {false, true}}),
+	}
+
+	for _, val := range colliders {
+		want, err := MarshalCollider(val)
+		if err != nil {
+			t.Fatalf("MarshalCollider(%T): %v", val, err)
+		}
+
+		got, err := json.Marshal(val)
+		if err != nil {
+			t.Fatalf("json.Marshal(%T): %v", val, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("json.Marshal(%T) = %s, want %s", val, got, want)
+		}
+
+		// Decode into a Collider of the same type, but a different shape
+		decoded, err := UnmarshalCollider(want)
+		if err != nil {
+			t.Fatalf("UnmarshalCollider(%s): %v", want, err)
+		}
+		decoded.Move(100, 100)
+		if err := json.Unmarshal(want, decoded); err != nil {
+			t.Fatalf("json.Unmarshal(%s) into %T: %v", want, decoded, err)
+		}
+		if got, _ := MarshalCollider(decoded); !bytes.Equal(got, want) {
+			t.Errorf("json.Unmarshal(%s) into %T decoded %s", want, decoded, got)
+		}
+	}
+
+	point := NewCollider([]float64{1, 2})
+	if err := json.Unmarshal([]byte(`{"type":"line","points":[[0,0],[1,1]]}`), point); err == nil {
+		t.Error("decoding a line into a point succeeded")
+	}
+}
+
+// binaryTestColliders returns a Collider of every type, along with a mask
+// Collider and one with collision filtering set up.
+func binaryTestColliders() []Collider {
+
+	mask := make([]byte, 3*2*4)
+	for i := 3; i < len(mask); i += 8 {
+		mask[i] = 255
+	}
+
+	filtered := NewCircleCollider(3, 4, 2)
+	filtered.SetCollisionCategory(4)
+	filtered.SetCollisionMask(0)
+	filtered.SetSensor(true)
+
+	return []Collider{
+		NewCollider([]float64{1, 2}),
+		NewCollider([]float64{0, 0, 10, 0, 10, 5, 0, 5}),
+		NewCollider([]float64{0, 0, 10, 10}),
+		NewCollider([]float64{0, 0, 10, 0, 5, 10}),
+		NewCircleCollider(3, 4, 2),
+		NewCapsuleCollider(0, 0, 10, 0, 1),
+		NewCompoundCollider(0, 0, NewCollider([]float64{0, 0, 10, 0, 10, 5, 0, 5}), NewCircleCollider(3, 4, 2)),
+		NewTileGridCollider(0, 0, 2, 2, [][]bool{{true, false}, {false, true}}),
+		NewMaskCollider(5, 6, 3, 2, mask, 128),
+		filtered,
+	}
+}
+
+func TestColliderBinary(t *testing.T) {
+
+	for _, val := range binaryTestColliders() {
+		data, err := MarshalColliderBinary(val)
+		if err != nil {
+			t.Fatalf("MarshalColliderBinary(%T): %v", val, err)
+		}
+
+		decoded, err := UnmarshalColliderBinary(data)
+		if err != nil {
+			t.Fatalf("UnmarshalColliderBinary(%v): %v", data, err)
+		}
+
+		want, _ := MarshalCollider(val)
+		if got, _ := MarshalCollider(decoded); !bytes.Equal(got, want) {
+			t.Errorf("binary round trip of %s decoded %s", want, got)
+		}
+		if got, _ := MarshalColliderBinary(decoded); !bytes.Equal(got, data) {
+			t.Errorf("binary round trip of %s changed its binary form", want)
+		}
+	}
+}
+
+func TestColliderBinaryInvalid(t *testing.T) {
+
+	for _, val := range binaryTestColliders() {
+		data, _ := MarshalColliderBinary(val)
+
+		for i := 0; i < len(data); i++ {
+			if _, err := UnmarshalColliderBinary(data[:i]); err == nil {
+				t.Errorf("decoding %T truncated to %d of %d bytes succeeded", val, i, len(data))
+			}
+		}
+
+		if _, err := UnmarshalColliderBinary(append(data, 0)); err == nil {
+			t.Errorf("decoding %T with trailing data succeeded", val)
+		}
+	}
+
+	for _, typ := range []byte{0, byte(tileGridColliderType) + 1, 255} {
+		if _, err := UnmarshalColliderBinary([]byte{colliderBinaryVersion, typ, 0}); err == nil {
+			t.Errorf("decoding unknown collider type %d succeeded", typ)
+		}
+	}
+
+	point, _ := MarshalColliderBinary(NewCollider([]float64{1, 2}))
+	point[0] = colliderBinaryVersion + 1
+	if _, err := UnmarshalColliderBinary(point); err == nil {
+		t.Error("decoding an unsupported format version succeeded")
+	}
+}
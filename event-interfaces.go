@@ -9,6 +9,39 @@ type CollisionEventResponder interface {
 	OnCollision(c1, c2 Collider, culprit interface{})
 }
 
+// CollisionManifoldEventResponder is an interface that requires methods that
+// allow an EventManager to supply a Manifold describing each collision. Objects
+// that implement this interface alongside CollisionEventResponder will have
+// their OnCollisionManifold method called in place of OnCollision.
+type CollisionManifoldEventResponder interface {
+	OnCollisionManifold(c1, c2 Collider, manifold Manifold, culprit interface{})
+}
+
+// CollisionBeginEventResponder is an interface that requires methods that
+// allow an EventManager to call the OnCollisionBegin method of an object when
+// one of its Colliders starts overlapping the Collider of another object.
+// Objects must also implement CollisionEventResponder.
+type CollisionBeginEventResponder interface {
+	OnCollisionBegin(c1, c2 Collider, culprit interface{})
+}
+
+// CollisionPersistEventResponder is an interface that requires methods that
+// allow an EventManager to call the OnCollisionPersist method of an object
+// for every collision event after the first in which two Colliders are still
+// overlapping. Objects must also implement CollisionEventResponder.
+type CollisionPersistEventResponder interface {
+	OnCollisionPersist(c1, c2 Collider, culprit interface{})
+}
+
+// CollisionEndEventResponder is an interface that requires methods that allow
+// an EventManager to call the OnCollisionEnd method of an object when one of
+// its Colliders stops overlapping the Collider of another object, including
+// when either object is removed from the EventManager. Objects must also
+// implement CollisionEventResponder.
+type CollisionEndEventResponder interface {
+	OnCollisionEnd(c1, c2 Collider, culprit interface{})
+}
+
 // KeyboardEventResponder is an interface that requires methods that allow an
 // EventManager to call the OnKeyboard method of an object when a keyboard
 // event happens. Objects that implement this interface will automatically be
@@ -104,6 +137,14 @@ type DrawEventResponder interface {
 	OnDraw()
 }
 
+// InterpolatedDrawEventResponder is an interface that requires methods that
+// allow an EventManager to draw an object at every frame, between its
+// positions at the last two ticks. Objects that implement this interface are
+// drawn with OnInterpolatedDraw instead of OnDraw when a Runner is used.
+type InterpolatedDrawEventResponder interface {
+	OnInterpolatedDraw(alpha float64)
+}
+
 // TickEventResponder is an interface that requires methods that allow an
 // EventManager to call on the OnTick method with every tick of the
 // EventManager. Objects that implement this interface will automatically be
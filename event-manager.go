@@ -1,15 +1,151 @@
 package paunch
 
+import (
+	"math"
+	"sort"
+)
+
 // EventManager triggers methods with the On- prefix when appropriate given the
 // objects supplied to it.
 type EventManager struct {
 	Objects []interface{}
+
+	broadPhase BroadPhase
+	cellSize   float64
+	index      broadPhaseIndex
+	proxies    map[interface{}][]*proxy
+	static     map[interface{}]bool
+	contacts   []collisionPair
+	contactSet map[collisionPair]bool
+}
+
+// collisionPair is a record of one object's Collider overlapping the Collider
+// of another object, the culprit.
+type collisionPair struct {
+	object    interface{}
+	culprit   interface{}
+	collider1 Collider
+	collider2 Collider
 }
 
 // NewEventManager creates a new EventManager.
 func NewEventManager() *EventManager {
 
-	return &EventManager{make([]interface{}, 0)}
+	return &EventManager{Objects: make([]interface{}, 0)}
+}
+
+// SetBroadPhase sets the strategy the EventManager uses to avoid testing
+// objects that are too far apart to collide. The default is AABBTree.
+func (eventManager *EventManager) SetBroadPhase(strategy BroadPhase) {
+
+	eventManager.broadPhase = strategy
+	eventManager.index, eventManager.proxies = nil, nil
+}
+
+// SetCellSize sets the width and height of the cells used by the SpatialHash
+// broad phase strategy. Cells should be about the size of a typical object.
+func (eventManager *EventManager) SetCellSize(size float64) {
+
+	eventManager.cellSize = size
+	eventManager.index, eventManager.proxies = nil, nil
+}
+
+// SetStatic sets whether or not the specified object is static. The Colliders
+// of static objects are only retrieved once, so they are not re-inserted into
+// the broad phase every tick, and static objects are not tested against each
+// other. Calling SetStatic again makes the EventManager retrieve the object's
+// Colliders anew, which is necessary if a static object is ever moved.
+func (eventManager *EventManager) SetStatic(object interface{}, static bool) {
+
+	if eventManager.static == nil {
+		eventManager.static = make(map[interface{}]bool)
+	}
+
+	if static {
+		eventManager.static[object] = true
+	} else {
+		delete(eventManager.static, object)
+	}
+
+	if proxies, ok := eventManager.proxies[object]; ok && eventManager.index != nil {
+		for _, val := range proxies {
+			eventManager.index.remove(val)
+		}
+		delete(eventManager.proxies, object)
+	}
+}
+
+// updateIndex brings the broad phase index up to date with the Colliders of
+// the EventManager's objects.
+func (eventManager *EventManager) updateIndex() {
+
+	if eventManager.index == nil {
+		eventManager.index = newBroadPhaseIndex(eventManager.broadPhase, eventManager.cellSize)
+		eventManager.proxies = make(map[interface{}][]*proxy)
+	}
+
+	present := make(map[interface{}]bool, len(eventManager.Objects))
+
+	for i, obj := range eventManager.Objects {
+		actorCollider, ok := obj.(CollisionEventResponder)
+		if !ok || present[obj] {
+			continue
+		}
+		present[obj] = true
+
+		proxies, tracked := eventManager.proxies[obj]
+		static := eventManager.static[obj]
+
+		if tracked && static {
+			for _, val := range proxies {
+				val.objectIndex = i
+			}
+			continue
+		}
+
+		colliders := actorCollider.GetColliders()
+
+		if tracked && len(proxies) == len(colliders) {
+			same := true
+			for j, val := range proxies {
+				if val.collider != colliders[j] {
+					same = false
+					break
+				}
+			}
+
+			if same {
+				for _, val := range proxies {
+					val.objectIndex = i
+					if val.refreshBounds() {
+						eventManager.index.update(val)
+					}
+				}
+				continue
+			}
+		}
+
+		for _, val := range proxies {
+			eventManager.index.remove(val)
+		}
+
+		proxies = make([]*proxy, len(colliders))
+		for j, val := range colliders {
+			proxies[j] = newProxy(obj, val, j, static)
+			proxies[j].objectIndex = i
+			eventManager.index.insert(proxies[j])
+		}
+		eventManager.proxies[obj] = proxies
+	}
+
+	for obj, proxies := range eventManager.proxies {
+		if !present[obj] {
+			for _, val := range proxies {
+				eventManager.index.remove(val)
+			}
+			delete(eventManager.proxies, obj)
+		}
+	}
 }
 
 // RunKeyEvent simulates a key event, triggering the expected response from
@@ -110,36 +246,103 @@ func (eventManager *EventManager) RunJoystickAxisEvent(device int, value float64
 }
 
 // RunCollisionEvent checks for collisions between the EventManager's objects
-// and triggers appropriate methods.
+// and triggers appropriate methods. A broad phase, set with SetBroadPhase,
+// rules out objects that are too far apart to collide, and Colliders whose
+// collision categories and masks do not match are never tested. Objects that
+// implement CollisionManifoldEventResponder are given a Manifold for each
+// collision that does not involve a sensor. The EventManager remembers which
+// Colliders overlapped during the previous call, so that objects implementing
+// CollisionBeginEventResponder, CollisionPersistEventResponder and
+// CollisionEndEventResponder are told when collisions start, continue and
+// stop. Collisions also stop when either object is removed from Objects.
+// Collisions involving compound Colliders are reported once for each pair of
+// overlapping children.
 func (eventManager *EventManager) RunCollisionEvent() {
 
+	type candidate struct {
+		proxy1 *proxy
+		proxy2 *proxy
+	}
+
+	eventManager.updateIndex()
+
+	var contacts []collisionPair
+	contactSet := make(map[collisionPair]bool)
+
 	for i := range eventManager.Objects {
 		actorCollider, ok := eventManager.Objects[i].(CollisionEventResponder)
 		if !ok {
 			continue
 		}
-		colliders1 := actorCollider.GetColliders()
+		manifoldCollider, wantsManifold := eventManager.Objects[i].(CollisionManifoldEventResponder)
 
-		for _, val := range eventManager.Objects {
-			if eventManager.Objects[i] == val {
-				continue
+		var candidates []candidate
+		for _, proxy1 := range eventManager.proxies[eventManager.Objects[i]] {
+			eventManager.index.query(proxy1.minX, proxy1.minY, proxy1.maxX, proxy1.maxY, func(proxy2 *proxy) {
+				if proxy2.object == proxy1.object || (proxy1.static && proxy2.static) {
+					return
+				}
+				candidates = append(candidates, candidate{proxy1, proxy2})
+			})
+		}
+
+		// Keep the order the objects and their Colliders were supplied in
+		sort.Slice(candidates, func(a, b int) bool {
+			if candidates[a].proxy2.objectIndex != candidates[b].proxy2.objectIndex {
+				return candidates[a].proxy2.objectIndex < candidates[b].proxy2.objectIndex
 			}
+			if candidates[a].proxy1.colliderIndex != candidates[b].proxy1.colliderIndex {
+				return candidates[a].proxy1.colliderIndex < candidates[b].proxy1.colliderIndex
+			}
+			return candidates[a].proxy2.colliderIndex < candidates[b].proxy2.colliderIndex
+		})
 
-			actorCollider2, ok2 := val.(CollisionEventResponder)
-			if !ok2 {
+		for _, val := range candidates {
+			if !canCollide(val.proxy1.collider, val.proxy2.collider) {
 				continue
 			}
-			colliders2 := actorCollider2.GetColliders()
+			resolvable := isResolvable(val.proxy1.collider, val.proxy2.collider)
+
+			// Compound Colliders report each of their overlapping children
+			for _, children := range getCollidingChildren(val.proxy1.collider, val.proxy2.collider, false) {
+				col1, col2 := children[0], children[1]
+
+				if wantsManifold && resolvable {
+					manifold, ok := CollisionManifold(col1, col2)
+					if !ok {
+						continue
+					}
+					manifoldCollider.OnCollisionManifold(col1, col2, manifold, val.proxy2.object)
+				} else {
+					actorCollider.OnCollision(col1, col2, val.proxy2.object)
+				}
 
-			for _, col1 := range colliders1 {
-				for _, col2 := range colliders2 {
-					if Collides(col1, col2) {
-						actorCollider.OnCollision(col1, col2, val)
+				pair := collisionPair{val.proxy1.object, val.proxy2.object, col1, col2}
+				contacts = append(contacts, pair)
+				contactSet[pair] = true
+
+				if eventManager.contactSet[pair] {
+					if responder, ok := pair.object.(CollisionPersistEventResponder); ok {
+						responder.OnCollisionPersist(col1, col2, pair.culprit)
 					}
+				} else if responder, ok := pair.object.(CollisionBeginEventResponder); ok {
+					responder.OnCollisionBegin(col1, col2, pair.culprit)
 				}
 			}
 		}
 	}
+
+	for _, val := range eventManager.contacts {
+		if contactSet[val] {
+			continue
+		}
+		if responder, ok := val.object.(CollisionEndEventResponder); ok {
+			responder.OnCollisionEnd(val.collider1, val.collider2, val.culprit)
+		}
+	}
+
+	eventManager.contacts = contacts
+	eventManager.contactSet = contactSet
 }
 
 // RunCharacterEvent simulates a character event, triggering the expected
@@ -175,25 +378,207 @@ func (eventManager *EventManager) RunDrawEvent() {
 	}
 }
 
+// RunInterpolatedDrawEvent runs a draw event, triggering the expected
+// response from the EventManager's objects. The alpha value, from 0 to 1, is
+// how far the current frame is between the last tick and the next one.
+// Objects that implement InterpolatedDrawEventResponder are given the alpha
+// value, and other objects that implement DrawEventResponder are drawn as
+// usual.
+func (eventManager *EventManager) RunInterpolatedDrawEvent(alpha float64) {
+
+	for i := range eventManager.Objects {
+		if val, ok := eventManager.Objects[i].(InterpolatedDrawEventResponder); ok {
+			val.OnInterpolatedDraw(alpha)
+		} else if val, ok := eventManager.Objects[i].(DrawEventResponder); ok {
+			val.OnDraw()
+		}
+	}
+}
+
 // Collides checks if the supplied Collider collides with any of the
-// EventManager's objects.
+// EventManager's objects, taking the collision categories and masks of the
+// Colliders into account.
 func (eventManager *EventManager) Collides(collider Collider) bool {
 
-	for i := range eventManager.Objects {
-		actorCollider, ok := eventManager.Objects[i].(CollisionEventResponder)
-		if !ok {
-			continue
+	eventManager.updateIndex()
+
+	collides := false
+	bounds := collider.getBounds()
+	eventManager.index.query(bounds.start.x, bounds.start.y, bounds.end.x, bounds.end.y, func(p *proxy) {
+		if !collides && canCollide(collider, p.collider) && Collides(collider, p.collider) {
+			collides = true
+		}
+	})
+
+	return collides
+}
+
+// QueryHit describes an object whose Collider overlaps the shape given to a
+// query.
+type QueryHit struct {
+	// Object is the EventManager object that owns the Collider.
+	Object interface{}
+	// Collider is the Collider of the object, as returned by GetColliders,
+	// that overlaps the shape.
+	Collider Collider
+}
+
+// QueryFilter decides whether or not an object's Collider should be included
+// in the results of a query.
+type QueryFilter func(object interface{}, collider Collider) bool
+
+// query returns every Collider of the EventManager's objects that overlaps
+// the given Collider and is accepted by the filter, in the order the objects
+// and their Colliders were supplied in.
+func (eventManager *EventManager) query(collider Collider, categories bool, filter QueryFilter) []QueryHit {
+
+	eventManager.updateIndex()
+
+	var proxies []*proxy
+	bounds := collider.getBounds()
+	eventManager.index.query(bounds.start.x, bounds.start.y, bounds.end.x, bounds.end.y, func(p *proxy) {
+		if p.collider == collider || (categories && !canCollide(collider, p.collider)) {
+			return
+		}
+		if (filter == nil || filter(p.object, p.collider)) && Collides(collider, p.collider) {
+			proxies = append(proxies, p)
+		}
+	})
+
+	sort.Slice(proxies, func(a, b int) bool {
+		if proxies[a].objectIndex != proxies[b].objectIndex {
+			return proxies[a].objectIndex < proxies[b].objectIndex
+		}
+		return proxies[a].colliderIndex < proxies[b].colliderIndex
+	})
+
+	hits := make([]QueryHit, len(proxies))
+	for i, val := range proxies {
+		hits[i] = QueryHit{Object: val.object, Collider: val.collider}
+	}
+
+	return hits
+}
+
+// QueryCollider returns every Collider of the EventManager's objects that
+// overlaps the supplied Collider, along with the object that owns it, in the
+// order the objects were supplied in. Like Collides, the collision categories
+// and masks of the Colliders are taken into account. The supplied Collider
+// never matches itself. If the filter is not nil, only Colliders it accepts
+// are returned.
+func (eventManager *EventManager) QueryCollider(collider Collider, filter QueryFilter) []QueryHit {
+
+	return eventManager.query(collider, true, filter)
+}
+
+// QueryRect returns every Collider of the EventManager's objects that
+// overlaps the rectangle with the corners x1, y1 and x2, y2, along with the
+// object that owns it. Collision categories and masks are ignored. If the
+// filter is not nil, only Colliders it accepts are returned.
+func (eventManager *EventManager) QueryRect(x1, y1, x2, y2 float64, filter QueryFilter) []QueryHit {
+
+	return eventManager.query(newBounding(newPoint(x1, y1), newPoint(x2, y2)), false, filter)
+}
+
+// QueryPoint returns every Collider of the EventManager's objects that
+// contains the point x, y, like the position of the mouse, along with the
+// object that owns it. Collision categories and masks are ignored. If the
+// filter is not nil, only Colliders it accepts are returned.
+func (eventManager *EventManager) QueryPoint(x, y float64, filter QueryFilter) []QueryHit {
+
+	return eventManager.query(newPoint(x, y), false, filter)
+}
+
+// RaycastAll casts a ray from the origin x, y toward the direction x, y and
+// returns every point where it strikes the Colliders of the EventManager's
+// objects within the maximum distance, sorted from nearest to farthest.
+func (eventManager *EventManager) RaycastAll(originX, originY, dirX, dirY, maxDistance float64) []RaycastHit {
+
+	var hits []RaycastHit
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok {
+		return hits
+	}
+
+	eventManager.updateIndex()
+
+	endX, endY := originX+(dirX*maxDistance), originY+(dirY*maxDistance)
+	if math.IsInf(maxDistance, 1) {
+		endX, endY = originX+(dirX*math.Inf(1)), originY+(dirY*math.Inf(1))
+		if dirX == 0 {
+			endX = originX
 		}
+		if dirY == 0 {
+			endY = originY
+		}
+	}
 
-		collisions := actorCollider.GetColliders()
-		for _, val := range collisions {
-			if Collides(collider, val) {
-				return true
+	eventManager.index.query(math.Min(originX, endX), math.Min(originY, endY),
+		math.Max(originX, endX), math.Max(originY, endY), func(p *proxy) {
+			if hit, ok := p.collider.Raycast(originX, originY, dirX, dirY, maxDistance); ok {
+				hit.Object = p.object
+				hits = append(hits, hit)
+			}
+		})
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		return hits[a].Distance < hits[b].Distance
+	})
+
+	return hits
+}
+
+// Raycast casts a ray from the origin x, y toward the direction x, y and
+// returns the nearest point where it strikes the Colliders of the
+// EventManager's objects within the maximum distance.
+func (eventManager *EventManager) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	hits := eventManager.RaycastAll(originX, originY, dirX, dirY, maxDistance)
+	if len(hits) == 0 {
+		return RaycastHit{}, false
+	}
+
+	return hits[0], true
+}
+
+// Sweep checks if the supplied Collider, if moved the specified distance,
+// would touch any of the Colliders of the EventManager's objects along the
+// way, and returns the SweepHit describing the first moment of contact. The
+// object that owns the supplied Collider, if any, is ignored, as are sensors
+// and Colliders whose collision categories and masks do not match.
+func (eventManager *EventManager) Sweep(collider Collider, x, y float64) (SweepHit, bool) {
+
+	eventManager.updateIndex()
+
+	var owner interface{}
+	for obj, proxies := range eventManager.proxies {
+		for _, val := range proxies {
+			if val.collider == collider {
+				owner = obj
 			}
 		}
 	}
 
-	return false
+	var first SweepHit
+	hit := false
+	bounds := collider.getBounds()
+	eventManager.index.query(bounds.start.x+math.Min(0, x), bounds.start.y+math.Min(0, y),
+		bounds.end.x+math.Max(0, x), bounds.end.y+math.Max(0, y), func(p *proxy) {
+			if p.object == owner || p.collider == collider ||
+				!canCollide(collider, p.collider) || !isResolvable(collider, p.collider) {
+				return
+			}
+
+			sweepHit, ok := Sweep(collider, x, y, p.collider)
+			if ok && (!hit || sweepHit.Time < first.Time) {
+				sweepHit.Object = p.object
+				first = sweepHit
+				hit = true
+			}
+		})
+
+	return first, hit
 }
 
 // GetUserEvents sets whether or not the EventManager object should be
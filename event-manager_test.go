@@ -0,0 +1,112 @@
+package paunch
+
+import (
+	"testing"
+)
+
+// testObject is an EventManager object that records the objects it collides
+// with.
+type testObject struct {
+	colliders []Collider
+	culprits  []interface{}
+}
+
+func newTestObject(colliders ...Collider) *testObject {
+
+	return &testObject{colliders: colliders}
+}
+
+func (obj *testObject) GetColliders() []Collider {
+
+	return obj.colliders
+}
+
+func (obj *testObject) OnCollision(c1, c2 Collider, culprit interface{}) {
+
+	obj.culprits = append(obj.culprits, culprit)
+}
+
+func TestEventManagerSetStaticAfterSetBroadPhase(t *testing.T) {
+
+	eventManager := NewEventManager()
+	obj := newTestObject(NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}))
+	other := newTestObject(NewCollider([]float64{5, 5}))
+	eventManager.Objects = append(eventManager.Objects, obj, other)
+
+	eventManager.RunCollisionEvent()
+	eventManager.SetBroadPhase(SpatialHash)
+	eventManager.SetStatic(obj, true)
+	eventManager.SetCellSize(4)
+	eventManager.SetStatic(obj, false)
+
+	other.culprits = nil
+	eventManager.RunCollisionEvent()
+	if len(other.culprits) != 1 || other.culprits[0] != obj {
+		t.Errorf("culprits = %v, want the static object", other.culprits)
+	}
+}
+
+func checkQueryHits(t *testing.T, name string, hits []QueryHit, want ...Collider) {
+
+	if len(hits) != len(want) {
+		t.Errorf("%s returned %d hits, want %d", name, len(hits), len(want))
+		return
+	}
+	for i, val := range hits {
+		if val.Collider != want[i] {
+			t.Errorf("%s hit %d is %T, want %T", name, i, val.Collider, want[i])
+		}
+	}
+}
+
+func TestEventManagerQueries(t *testing.T) {
+
+	ground := newTestObject(NewCollider([]float64{0, 0, 100, 0, 100, 10, 0, 10}))
+	player := newTestObject(NewCircleCollider(20, 15, 5), NewCollider([]float64{20, 15}))
+	coin := newTestObject(NewCollider([]float64{60, 20, 64, 20, 64, 24, 60, 24}))
+	coin.colliders[0].SetSensor(true)
+	ghost := newTestObject(NewCollider([]float64{40, 5, 50, 5, 50, 15, 40, 15}))
+	ghost.colliders[0].SetCollisionCategory(2)
+	ghost.colliders[0].SetCollisionMask(0)
+
+	eventManager := NewEventManager()
+	eventManager.Objects = append(eventManager.Objects, player, ground, coin, ghost)
+
+	checkQueryHits(t, "QueryRect", eventManager.QueryRect(15, 5, 62, 22, nil),
+		player.colliders[0], player.colliders[1], ground.colliders[0], coin.colliders[0], ghost.colliders[0])
+	checkQueryHits(t, "QueryRect with swapped corners", eventManager.QueryRect(62, 22, 15, 5, nil),
+		player.colliders[0], player.colliders[1], ground.colliders[0], coin.colliders[0], ghost.colliders[0])
+	checkQueryHits(t, "QueryRect off to the side", eventManager.QueryRect(200, 200, 300, 300, nil))
+
+	checkQueryHits(t, "QueryPoint", eventManager.QueryPoint(45, 8, nil), ground.colliders[0], ghost.colliders[0])
+	checkQueryHits(t, "QueryPoint in empty space", eventManager.QueryPoint(80, 50, nil))
+
+	// Only the object's first Collider, without the ground
+	first := func(object interface{}, collider Collider) bool {
+		return object != ground && collider == object.(*testObject).colliders[0]
+	}
+	checkQueryHits(t, "QueryRect with a filter", eventManager.QueryRect(15, 5, 62, 22, first),
+		player.colliders[0], coin.colliders[0], ghost.colliders[0])
+	checkQueryHits(t, "QueryPoint with a filter", eventManager.QueryPoint(45, 8, first), ghost.colliders[0])
+
+	// The ghost's mask rules it out, and the player's Colliders are never
+	// queried against themselves
+	checkQueryHits(t, "QueryCollider", eventManager.QueryCollider(player.colliders[0], nil),
+		player.colliders[1], ground.colliders[0])
+	checkQueryHits(t, "QueryCollider of a new Collider",
+		eventManager.QueryCollider(NewCollider([]float64{38, 12, 62, 12, 62, 21, 38, 21}), nil),
+		coin.colliders[0])
+	checkQueryHits(t, "QueryCollider with a filter", eventManager.QueryCollider(player.colliders[0],
+		func(object interface{}, collider Collider) bool {
+			return object != player
+		}), ground.colliders[0])
+
+	rejected := 0
+	eventManager.QueryRect(0, 0, 100, 100, func(object interface{}, collider Collider) bool {
+		rejected++
+		return false
+	})
+	if rejected == 0 {
+		t.Error("the filter was never called")
+	}
+}
@@ -7,7 +7,6 @@ package main
 
 import (
 	"github.com/velovix/paunch"
-	"time"
 )
 
 var (
@@ -78,27 +77,15 @@ func main() {
 	eventManager.Objects = []interface{}{&player} // Add the Player object to the EventManager's object list.
 	eventManager.GetUserEvents(true)              // Set the EventManager to automatically respond to user events.
 
-	lastFrame := time.Now()
+	effect.SetVariable2f("screen_size", 640, 480)
+	effect.SetVariablei("tex_id", 0)
 
-	for !paunch.ShouldClose() {
-
-		effect.SetVariable2f("screen_size", 640, 480)
-		effect.SetVariablei("tex_id", 0)
-
-		paunch.Clear()
-
-		// Only check for events 1/60th of a second. This operation is not
-		// Paunch-specific, but effectively limits the physics framerate to
-		// 60 FPS.
-		if time.Since(lastFrame).Seconds() >= (1.0 / 60.0) {
-			lastFrame = time.Now()
-			paunch.UpdateEvents()
-		}
-
-		// Has the EventManager run a draw event, which calls the OnDraw
-		// methods of it's objects.
-		eventManager.RunDrawEvent()
-
-		paunch.UpdateDisplay()
+	// The Runner updates events 60 times a second, no matter how quickly
+	// frames are drawn, and has the EventManager run a draw event, which
+	// calls the OnDraw methods of it's objects, every frame.
+	runner := paunch.NewRunner(60, eventManager)
+	err = runner.Run()
+	if err != nil {
+		panic(err)
 	}
 }
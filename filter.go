@@ -0,0 +1,77 @@
+package paunch
+
+// DefaultCollisionCategory is the collision category that Collider objects
+// belong to until they are given another one.
+const DefaultCollisionCategory uint32 = 1
+
+// collisionFilter holds the collision category, mask and sensor flag of a
+// Collider. Its zero value belongs to DefaultCollisionCategory and collides
+// with every category.
+type collisionFilter struct {
+	category uint32
+	// ignored is the inverse of the collision mask, so that the zero value
+	// collides with everything.
+	ignored uint32
+	sensor  bool
+}
+
+// SetCollisionCategory sets the categories the Collider object belongs to as
+// a bit field. A value of zero resets the Collider object to
+// DefaultCollisionCategory.
+func (filter *collisionFilter) SetCollisionCategory(category uint32) {
+
+	filter.category = category
+}
+
+// CollisionCategory returns the categories the Collider object belongs to as
+// a bit field.
+func (filter *collisionFilter) CollisionCategory() uint32 {
+
+	if filter.category == 0 {
+		return DefaultCollisionCategory
+	}
+
+	return filter.category
+}
+
+// SetCollisionMask sets the categories the Collider object can collide with
+// as a bit field. By default, Collider objects collide with every category.
+func (filter *collisionFilter) SetCollisionMask(mask uint32) {
+
+	filter.ignored = ^mask
+}
+
+// CollisionMask returns the categories the Collider object can collide with
+// as a bit field.
+func (filter *collisionFilter) CollisionMask() uint32 {
+
+	return ^filter.ignored
+}
+
+// SetSensor sets whether or not the Collider object is a sensor. Sensors
+// report collisions but are never used to push objects apart.
+func (filter *collisionFilter) SetSensor(sensor bool) {
+
+	filter.sensor = sensor
+}
+
+// IsSensor returns true if the Collider object is a sensor.
+func (filter *collisionFilter) IsSensor() bool {
+
+	return filter.sensor
+}
+
+// canCollide returns true if the categories and masks of the two Colliders
+// allow them to collide with each other.
+func canCollide(collider1, collider2 Collider) bool {
+
+	return collider1.CollisionCategory()&collider2.CollisionMask() != 0 &&
+		collider2.CollisionCategory()&collider1.CollisionMask() != 0
+}
+
+// isResolvable returns true if a collision between the two Colliders should
+// be used to push them apart.
+func isResolvable(collider1, collider2 Collider) bool {
+
+	return !collider1.IsSensor() && !collider2.IsSensor()
+}
@@ -0,0 +1,93 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+)
+
+// NewColliderFromGeometry creates a new Collider object with the shape of the
+// supplied geometry value. Supported types are geometry.Vec2, geometry.Rect,
+// geometry.Segment, geometry.Polygon, geometry.Circle, geometry.Capsule and
+// geometry.Group, which creates a compound Collider. Nil is returned for any
+// other type. NewCollider accepts the same values.
+func NewColliderFromGeometry(shape geometry.Shape) Collider {
+
+	switch shape := shape.(type) {
+	case geometry.Vec2:
+		return newPoint(shape.X, shape.Y)
+	case geometry.Rect:
+		return newBounding(newPoint(shape.Min.X, shape.Min.Y), newPoint(shape.Max.X, shape.Max.Y))
+	case geometry.Segment:
+		return newLine(newPoint(shape.Start.X, shape.Start.Y), newPoint(shape.End.X, shape.End.Y))
+	case geometry.Polygon:
+		if len(shape.Points) == 0 {
+			return nil
+		}
+		points := make([]*point, len(shape.Points))
+		for i, val := range shape.Points {
+			points[i] = newPoint(val.X, val.Y)
+		}
+		return newPolygon(points)
+	case geometry.Circle:
+		return NewCircleCollider(shape.Center.X, shape.Center.Y, shape.Radius)
+	case geometry.Capsule:
+		return NewCapsuleCollider(shape.Start.X, shape.Start.Y, shape.End.X, shape.End.Y, shape.Radius)
+	case geometry.Group:
+		if len(shape.Shapes) == 0 {
+			return nil
+		}
+		children := make([]Collider, len(shape.Shapes))
+		for i, val := range shape.Shapes {
+			children[i] = NewColliderFromGeometry(val)
+			if children[i] == nil {
+				return nil
+			}
+		}
+		bounds := shape.Bounds()
+		return newCompound(bounds.Min.X, bounds.Min.Y, children)
+	default:
+		return nil
+	}
+}
+
+// Geometry returns the shape of the point as a geometry.Vec2.
+func (p *point) Geometry() geometry.Shape {
+
+	return geometry.Vec2{X: p.x, Y: p.y}
+}
+
+// Geometry returns the shape of the bounding box as a geometry.Rect, or as a
+// geometry.Polygon if it has been rotated.
+func (b *bounding) Geometry() geometry.Shape {
+
+	if b.oriented != nil {
+		return b.oriented.Geometry()
+	}
+
+	return geometry.Rect{Min: geometry.Vec2{X: b.start.x, Y: b.start.y},
+		Max: geometry.Vec2{X: b.end.x, Y: b.end.y}}
+}
+
+// Geometry returns the shape of the line as a geometry.Segment. The start of
+// the segment is always its leftmost point.
+func (l *line) Geometry() geometry.Shape {
+
+	return geometry.Segment{Start: geometry.Vec2{X: l.start.x, Y: l.start.y},
+		End: geometry.Vec2{X: l.end.x, Y: l.end.y}}
+}
+
+// Geometry returns the shape of the polygon as a geometry.Polygon.
+func (poly *polygon) Geometry() geometry.Shape {
+
+	points := make([]geometry.Vec2, len(poly.points))
+	for i, val := range poly.points {
+		points[i] = geometry.Vec2{X: val.x, Y: val.y}
+	}
+
+	return geometry.Polygon{Points: points}
+}
+
+// Geometry returns the shape of the circle as a geometry.Circle.
+func (c *circle) Geometry() geometry.Shape {
+
+	return geometry.Circle{Center: geometry.Vec2{X: c.center.x, Y: c.center.y}, Radius: c.radius}
+}
@@ -0,0 +1,40 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Capsule is the set of points within a radius of a line segment, which
+// looks like a rectangle with rounded ends.
+type Capsule struct {
+	Start, End Vec2
+	Radius     float64
+}
+
+// Segment returns the line segment running down the middle of the Capsule.
+func (c Capsule) Segment() Segment {
+
+	return Segment{c.Start, c.End}
+}
+
+// Area returns the area of the Capsule.
+func (c Capsule) Area() float64 {
+
+	return (math.Pi * c.Radius * c.Radius) + (2 * c.Radius * c.Start.Distance(c.End))
+}
+
+// Bounds returns the smallest Rect that contains the Capsule.
+func (c Capsule) Bounds() Rect {
+
+	bounds := c.Segment().Bounds()
+
+	return Rect{Vec2{bounds.Min.X - c.Radius, bounds.Min.Y - c.Radius},
+		Vec2{bounds.Max.X + c.Radius, bounds.Max.Y + c.Radius}}
+}
+
+// Contains returns true if the point is inside or on the edge of the
+// Capsule.
+func (c Capsule) Contains(p Vec2) bool {
+
+	return c.Segment().ClosestPoint(p).Distance(p) <= c.Radius
+}
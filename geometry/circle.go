@@ -0,0 +1,30 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Circle is a circle described by its center and radius.
+type Circle struct {
+	Center Vec2
+	Radius float64
+}
+
+// Area returns the area of the Circle.
+func (c Circle) Area() float64 {
+
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Bounds returns the smallest Rect that contains the Circle.
+func (c Circle) Bounds() Rect {
+
+	return Rect{Vec2{c.Center.X - c.Radius, c.Center.Y - c.Radius},
+		Vec2{c.Center.X + c.Radius, c.Center.Y + c.Radius}}
+}
+
+// Contains returns true if the point is inside or on the edge of the Circle.
+func (c Circle) Contains(p Vec2) bool {
+
+	return c.Center.Distance(p) <= c.Radius
+}
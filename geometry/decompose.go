@@ -0,0 +1,213 @@
+package geometry
+
+import (
+	"math"
+)
+
+// outline returns the points of the Polygon in counter-clockwise order, with
+// repeated and collinear points removed.
+func (poly Polygon) outline() []Vec2 {
+
+	points := make([]Vec2, 0, len(poly.Points))
+	for i, val := range poly.Points {
+		if i == 0 || val.Distance(poly.Points[i-1]) > epsilon {
+			points = append(points, val)
+		}
+	}
+	for len(points) > 1 && points[0].Distance(points[len(points)-1]) <= epsilon {
+		points = points[:len(points)-1]
+	}
+
+	if (Polygon{points}).SignedArea() < 0 {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+
+	for removed := true; removed && len(points) > 3; {
+		removed = false
+		for i := range points {
+			prev := points[(i+len(points)-1)%len(points)]
+			next := points[(i+1)%len(points)]
+			if math.Abs(points[i].Sub(prev).Cross(next.Sub(points[i]))) <= epsilon {
+				points = append(points[:i], points[i+1:]...)
+				removed = true
+				break
+			}
+		}
+	}
+
+	return points
+}
+
+// inTriangle returns true if the point is inside or on the edge of the
+// counter-clockwise triangle.
+func inTriangle(p, a, b, c Vec2) bool {
+
+	return b.Sub(a).Cross(p.Sub(a)) >= -epsilon &&
+		c.Sub(b).Cross(p.Sub(b)) >= -epsilon &&
+		a.Sub(c).Cross(p.Sub(c)) >= -epsilon
+}
+
+// triangulate splits the outline into triangles using ear clipping, returning
+// each triangle as the indices of its points in counter-clockwise order.
+func triangulate(points []Vec2) [][]int {
+
+	remaining := make([]int, len(points))
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	var triangles [][]int
+	for len(remaining) > 3 {
+		clipped := false
+		for i := range remaining {
+			prev := remaining[(i+len(remaining)-1)%len(remaining)]
+			curr := remaining[i]
+			next := remaining[(i+1)%len(remaining)]
+
+			a, b, c := points[prev], points[curr], points[next]
+			if b.Sub(a).Cross(c.Sub(b)) <= epsilon {
+				continue
+			}
+
+			// An ear cannot contain any of the other points
+			ear := true
+			for _, val := range remaining {
+				if val == prev || val == curr || val == next {
+					continue
+				}
+				if inTriangle(points[val], a, b, c) {
+					ear = false
+					break
+				}
+			}
+			if !ear {
+				continue
+			}
+
+			triangles = append(triangles, []int{prev, curr, next})
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			clipped = true
+			break
+		}
+
+		// Polygons that intersect themselves may have no ears left
+		if !clipped {
+			break
+		}
+	}
+
+	return append(triangles, remaining)
+}
+
+// isConvexPiece returns true if the indexed points turn left at every corner.
+func isConvexPiece(points []Vec2, piece []int) bool {
+
+	for i := range piece {
+		a := points[piece[i]]
+		b := points[piece[(i+1)%len(piece)]]
+		c := points[piece[(i+2)%len(piece)]]
+		if b.Sub(a).Cross(c.Sub(b)) < -epsilon {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mergePieces joins two pieces that share the edge from a to b in the first
+// piece, which runs from b to a in the second.
+func mergePieces(piece1, piece2 []int, a, b int) []int {
+
+	rotate := func(piece []int, first int) []int {
+		for i, val := range piece {
+			if val == first {
+				return append(append([]int{}, piece[i:]...), piece[:i]...)
+			}
+		}
+		return piece
+	}
+
+	// The first piece becomes b ... a and the second a ... b
+	piece1 = rotate(piece1, b)
+	piece2 = rotate(piece2, a)
+
+	return append(piece1, piece2[1:len(piece2)-1]...)
+}
+
+// Triangulate splits a simple Polygon into counter-clockwise triangles.
+func (poly Polygon) Triangulate() []Polygon {
+
+	points := poly.outline()
+	if len(points) < 3 {
+		return []Polygon{{points}}
+	}
+
+	return toPolygons(points, triangulate(points))
+}
+
+// Decompose splits a simple Polygon into counter-clockwise convex Polygons.
+// The Polygon is triangulated, and then neighboring pieces are merged for as
+// long as the result stays convex, which produces at most four times the
+// smallest possible number of pieces. Convex Polygons are returned whole.
+func (poly Polygon) Decompose() []Polygon {
+
+	points := poly.outline()
+	if len(points) < 3 || (Polygon{points}).IsConvex() {
+		return []Polygon{{points}}
+	}
+
+	pieces := triangulate(points)
+
+	for merged := true; merged; {
+		merged = false
+
+		for i := 0; i < len(pieces) && !merged; i++ {
+			for j := i + 1; j < len(pieces) && !merged; j++ {
+				for k := range pieces[i] {
+					a := pieces[i][k]
+					b := pieces[i][(k+1)%len(pieces[i])]
+					if !hasEdge(pieces[j], b, a) {
+						continue
+					}
+
+					candidate := mergePieces(pieces[i], pieces[j], a, b)
+					if isConvexPiece(points, candidate) {
+						pieces[i] = candidate
+						pieces = append(pieces[:j], pieces[j+1:]...)
+						merged = true
+					}
+					break
+				}
+			}
+		}
+	}
+
+	return toPolygons(points, pieces)
+}
+
+// hasEdge returns true if the piece has an edge running from a to b.
+func hasEdge(piece []int, a, b int) bool {
+
+	for i, val := range piece {
+		if val == a && piece[(i+1)%len(piece)] == b {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toPolygons(points []Vec2, pieces [][]int) []Polygon {
+
+	polygons := make([]Polygon, len(pieces))
+	for i, piece := range pieces {
+		polygons[i].Points = make([]Vec2, len(piece))
+		for j, val := range piece {
+			polygons[i].Points[j] = points[val]
+		}
+	}
+
+	return polygons
+}
@@ -0,0 +1,34 @@
+package geometry
+
+// Group is a collection of Shapes that are treated as one.
+type Group struct {
+	Shapes []Shape
+}
+
+// Bounds returns the smallest Rect that contains every Shape in the Group.
+func (g Group) Bounds() Rect {
+
+	if len(g.Shapes) == 0 {
+		return Rect{}
+	}
+
+	bounds := g.Shapes[0].Bounds()
+	for _, val := range g.Shapes[1:] {
+		bounds = bounds.Union(val.Bounds())
+	}
+
+	return bounds
+}
+
+// Contains returns true if the point is inside or on the edge of any Shape in
+// the Group.
+func (g Group) Contains(p Vec2) bool {
+
+	for _, val := range g.Shapes {
+		if val.Contains(p) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,179 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+// Polygon is a closed shape made of straight edges between its points.
+type Polygon struct {
+	Points []Vec2
+}
+
+// Edges returns the Segments that make up the outline of the Polygon.
+func (poly Polygon) Edges() []Segment {
+
+	edges := make([]Segment, len(poly.Points))
+	for i := range poly.Points {
+		edges[i] = Segment{poly.Points[i], poly.Points[(i+1)%len(poly.Points)]}
+	}
+
+	return edges
+}
+
+// SignedArea returns the area of the Polygon, which is positive if its points
+// are in counter-clockwise order and negative otherwise.
+func (poly Polygon) SignedArea() float64 {
+
+	area := 0.0
+	for i := range poly.Points {
+		area += poly.Points[i].Cross(poly.Points[(i+1)%len(poly.Points)])
+	}
+
+	return area / 2
+}
+
+// Area returns the area of the Polygon.
+func (poly Polygon) Area() float64 {
+
+	return math.Abs(poly.SignedArea())
+}
+
+// Centroid returns the center of mass of the Polygon. Polygons with no area
+// return the average of their points.
+func (poly Polygon) Centroid() Vec2 {
+
+	if len(poly.Points) == 0 {
+		return Vec2{}
+	}
+
+	area := poly.SignedArea()
+	if math.Abs(area) <= epsilon {
+		var sum Vec2
+		for _, val := range poly.Points {
+			sum = sum.Add(val)
+		}
+		return sum.Scale(1 / float64(len(poly.Points)))
+	}
+
+	var centroid Vec2
+	for i := range poly.Points {
+		p1, p2 := poly.Points[i], poly.Points[(i+1)%len(poly.Points)]
+		cross := p1.Cross(p2)
+		centroid = centroid.Add(p1.Add(p2).Scale(cross))
+	}
+
+	return centroid.Scale(1 / (6 * area))
+}
+
+// Bounds returns the smallest Rect that contains the Polygon.
+func (poly Polygon) Bounds() Rect {
+
+	if len(poly.Points) == 0 {
+		return Rect{}
+	}
+
+	bounds := poly.Points[0].Bounds()
+	for _, val := range poly.Points[1:] {
+		bounds = bounds.Union(val.Bounds())
+	}
+
+	return bounds
+}
+
+// Contains returns true if the point is inside or on the edge of the
+// Polygon.
+func (poly Polygon) Contains(p Vec2) bool {
+
+	inside := false
+	for _, val := range poly.Edges() {
+		if val.Contains(p) {
+			return true
+		}
+
+		if (val.Start.Y > p.Y) != (val.End.Y > p.Y) {
+			crossX := val.Start.X + ((p.Y-val.Start.Y)/(val.End.Y-val.Start.Y))*(val.End.X-val.Start.X)
+			if p.X < crossX {
+				inside = !inside
+			}
+		}
+	}
+
+	return inside
+}
+
+// IsConvex returns true if the Polygon has no inward-pointing corners.
+func (poly Polygon) IsConvex() bool {
+
+	sign := 0.0
+	for i := range poly.Points {
+		p1 := poly.Points[i]
+		p2 := poly.Points[(i+1)%len(poly.Points)]
+		p3 := poly.Points[(i+2)%len(poly.Points)]
+
+		cross := p2.Sub(p1).Cross(p3.Sub(p2))
+		if math.Abs(cross) <= epsilon {
+			continue
+		}
+		if sign == 0 {
+			sign = cross
+		} else if (sign > 0) != (cross > 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ConvexHull returns the smallest convex Polygon that contains the Polygon.
+func (poly Polygon) ConvexHull() Polygon {
+
+	return ConvexHull(poly.Points)
+}
+
+// ConvexHull returns the smallest convex Polygon that contains all of the
+// points, in counter-clockwise order.
+func ConvexHull(points []Vec2) Polygon {
+
+	sorted := make([]Vec2, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+
+	unique := sorted[:0]
+	for i, val := range sorted {
+		if i == 0 || val != sorted[i-1] {
+			unique = append(unique, val)
+		}
+	}
+	sorted = unique
+
+	turn := func(o, a, b Vec2) float64 {
+		return a.Sub(o).Cross(b.Sub(o))
+	}
+
+	var hull []Vec2
+	for _, val := range sorted {
+		for len(hull) >= 2 && turn(hull[len(hull)-2], hull[len(hull)-1], val) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, val)
+	}
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		for len(hull) >= lower && turn(hull[len(hull)-2], hull[len(hull)-1], sorted[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, sorted[i])
+	}
+
+	if len(hull) > 1 {
+		hull = hull[:len(hull)-1]
+	}
+
+	return Polygon{hull}
+}
@@ -0,0 +1,84 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Rect is an axis-aligned rectangle described by its minimum and maximum
+// corners.
+type Rect struct {
+	Min, Max Vec2
+}
+
+// NewRect creates a new Rect from any two opposite corners.
+func NewRect(x1, y1, x2, y2 float64) Rect {
+
+	return Rect{Vec2{math.Min(x1, x2), math.Min(y1, y2)}, Vec2{math.Max(x1, x2), math.Max(y1, y2)}}
+}
+
+// Width returns the width of the Rect.
+func (r Rect) Width() float64 {
+
+	return r.Max.X - r.Min.X
+}
+
+// Height returns the height of the Rect.
+func (r Rect) Height() float64 {
+
+	return r.Max.Y - r.Min.Y
+}
+
+// Area returns the area of the Rect.
+func (r Rect) Area() float64 {
+
+	return r.Width() * r.Height()
+}
+
+// Centroid returns the center of the Rect.
+func (r Rect) Centroid() Vec2 {
+
+	return Vec2{(r.Min.X + r.Max.X) / 2, (r.Min.Y + r.Max.Y) / 2}
+}
+
+// Bounds returns the Rect itself.
+func (r Rect) Bounds() Rect {
+
+	return r
+}
+
+// Contains returns true if the point is inside or on the edge of the Rect.
+func (r Rect) Contains(p Vec2) bool {
+
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// Overlaps returns true if the two Rects share any area or edges.
+func (r Rect) Overlaps(r2 Rect) bool {
+
+	return r.Min.X <= r2.Max.X && r.Max.X >= r2.Min.X && r.Min.Y <= r2.Max.Y && r.Max.Y >= r2.Min.Y
+}
+
+// Intersect returns the area shared by the two Rects, reporting false if they
+// do not overlap.
+func (r Rect) Intersect(r2 Rect) (Rect, bool) {
+
+	if !r.Overlaps(r2) {
+		return Rect{}, false
+	}
+
+	return Rect{Vec2{math.Max(r.Min.X, r2.Min.X), math.Max(r.Min.Y, r2.Min.Y)},
+		Vec2{math.Min(r.Max.X, r2.Max.X), math.Min(r.Max.Y, r2.Max.Y)}}, true
+}
+
+// Union returns the smallest Rect that contains both Rects.
+func (r Rect) Union(r2 Rect) Rect {
+
+	return Rect{Vec2{math.Min(r.Min.X, r2.Min.X), math.Min(r.Min.Y, r2.Min.Y)},
+		Vec2{math.Max(r.Max.X, r2.Max.X), math.Max(r.Max.Y, r2.Max.Y)}}
+}
+
+// Polygon returns the corners of the Rect as a counter-clockwise Polygon.
+func (r Rect) Polygon() Polygon {
+
+	return Polygon{[]Vec2{r.Min, {r.Max.X, r.Min.Y}, r.Max, {r.Min.X, r.Max.Y}}}
+}
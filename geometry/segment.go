@@ -0,0 +1,83 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Segment is a line segment between two points.
+type Segment struct {
+	Start, End Vec2
+}
+
+// Length returns the length of the Segment.
+func (s Segment) Length() float64 {
+
+	return s.Start.Distance(s.End)
+}
+
+// Bounds returns the smallest Rect that contains the Segment.
+func (s Segment) Bounds() Rect {
+
+	return NewRect(s.Start.X, s.Start.Y, s.End.X, s.End.Y)
+}
+
+// ClosestPoint returns the point on the Segment closest to the given point.
+func (s Segment) ClosestPoint(p Vec2) Vec2 {
+
+	dir := s.End.Sub(s.Start)
+	lengthSquared := dir.Dot(dir)
+	if lengthSquared == 0 {
+		return s.Start
+	}
+
+	t := math.Max(0, math.Min(1, p.Sub(s.Start).Dot(dir)/lengthSquared))
+
+	return s.Start.Add(dir.Scale(t))
+}
+
+// Contains returns true if the point lies on the Segment.
+func (s Segment) Contains(p Vec2) bool {
+
+	return s.ClosestPoint(p).Distance(p) <= epsilon
+}
+
+// Intersect returns the point where the two Segments cross, reporting false
+// if they do not. Collinear Segments that overlap return the overlapping
+// point closest to the first Segment's start.
+func (s Segment) Intersect(s2 Segment) (Vec2, bool) {
+
+	dir1 := s.End.Sub(s.Start)
+	dir2 := s2.End.Sub(s2.Start)
+	toStart := s2.Start.Sub(s.Start)
+	denominator := dir1.Cross(dir2)
+
+	if math.Abs(denominator) <= epsilon {
+		if math.Abs(toStart.Cross(dir1)) > epsilon {
+			return Vec2{}, false
+		}
+
+		// The Segments are collinear, so find the overlap along the first
+		lengthSquared := dir1.Dot(dir1)
+		if lengthSquared == 0 {
+			if s2.Contains(s.Start) {
+				return s.Start, true
+			}
+			return Vec2{}, false
+		}
+		t1 := toStart.Dot(dir1) / lengthSquared
+		t2 := s2.End.Sub(s.Start).Dot(dir1) / lengthSquared
+		tMin, tMax := math.Max(0, math.Min(t1, t2)), math.Min(1, math.Max(t1, t2))
+		if tMin > tMax {
+			return Vec2{}, false
+		}
+		return s.Start.Add(dir1.Scale(tMin)), true
+	}
+
+	t := toStart.Cross(dir2) / denominator
+	u := toStart.Cross(dir1) / denominator
+	if t < -epsilon || t > 1+epsilon || u < -epsilon || u > 1+epsilon {
+		return Vec2{}, false
+	}
+
+	return s.Start.Add(dir1.Scale(t)), true
+}
@@ -0,0 +1,53 @@
+package geometry
+
+// simplifyChain applies the Douglas-Peucker algorithm to an open chain of
+// points, keeping its first and last points.
+func simplifyChain(points []Vec2, tolerance float64) []Vec2 {
+
+	if len(points) < 3 {
+		return points
+	}
+
+	edge := Segment{points[0], points[len(points)-1]}
+	farthest, farthestDist := 0, -1.0
+	for i := 1; i < len(points)-1; i++ {
+		if dist := edge.ClosestPoint(points[i]).Distance(points[i]); dist > farthestDist {
+			farthest, farthestDist = i, dist
+		}
+	}
+
+	if farthestDist <= tolerance {
+		return []Vec2{points[0], points[len(points)-1]}
+	}
+
+	first := simplifyChain(points[:farthest+1], tolerance)
+	second := simplifyChain(points[farthest:], tolerance)
+
+	return append(first[:len(first)-1:len(first)-1], second...)
+}
+
+// Simplify returns a version of the Polygon with fewer points, where no
+// removed point was farther than the tolerance from the new outline. The
+// Douglas-Peucker algorithm is used.
+func (poly Polygon) Simplify(tolerance float64) Polygon {
+
+	if len(poly.Points) < 4 {
+		return poly
+	}
+
+	// Split the outline at the point farthest from the first, since both
+	// are sure to be kept
+	farthest, farthestDist := 0, -1.0
+	for i, val := range poly.Points {
+		if dist := val.Distance(poly.Points[0]); dist > farthestDist {
+			farthest, farthestDist = i, dist
+		}
+	}
+
+	first := simplifyChain(poly.Points[:farthest+1], tolerance)
+	second := simplifyChain(append(append([]Vec2{}, poly.Points[farthest:]...), poly.Points[0]), tolerance)
+
+	points := append(append([]Vec2{}, first[:len(first)-1]...), second[:len(second)-1]...)
+
+	return Polygon{points}
+}
@@ -0,0 +1,115 @@
+// Package geometry provides the 2D value types that Paunch's Colliders are
+// built from. It has no dependencies outside of the standard library, so it
+// can be used by tools that do not open a window.
+package geometry
+
+import (
+	"math"
+)
+
+// epsilon is the distance under which two values are considered equal.
+const epsilon = 1e-9
+
+// Shape is a geometric figure that can report its bounds and whether it
+// contains a point.
+type Shape interface {
+	// Bounds returns the smallest Rect that contains the Shape.
+	Bounds() Rect
+	// Contains returns true if the point is inside or on the edge of the
+	// Shape.
+	Contains(p Vec2) bool
+}
+
+// Vec2 is a two dimensional vector, which can also be used as a point.
+type Vec2 struct {
+	X, Y float64
+}
+
+// Add returns the sum of the two vectors.
+func (v Vec2) Add(v2 Vec2) Vec2 {
+
+	return Vec2{v.X + v2.X, v.Y + v2.Y}
+}
+
+// Sub returns the difference of the two vectors.
+func (v Vec2) Sub(v2 Vec2) Vec2 {
+
+	return Vec2{v.X - v2.X, v.Y - v2.Y}
+}
+
+// Scale returns the vector multiplied by a scalar.
+func (v Vec2) Scale(s float64) Vec2 {
+
+	return Vec2{v.X * s, v.Y * s}
+}
+
+// Dot returns the dot product of the two vectors.
+func (v Vec2) Dot(v2 Vec2) float64 {
+
+	return (v.X * v2.X) + (v.Y * v2.Y)
+}
+
+// Cross returns the z component of the cross product of the two vectors. It
+// is positive when the second vector is counter-clockwise from the first.
+func (v Vec2) Cross(v2 Vec2) float64 {
+
+	return (v.X * v2.Y) - (v.Y * v2.X)
+}
+
+// Length returns the length of the vector.
+func (v Vec2) Length() float64 {
+
+	return math.Hypot(v.X, v.Y)
+}
+
+// Distance returns the distance between the two points.
+func (v Vec2) Distance(v2 Vec2) float64 {
+
+	return v2.Sub(v).Length()
+}
+
+// Normalize returns a vector with the same direction and a length of one. A
+// vector with no length is returned as-is.
+func (v Vec2) Normalize() Vec2 {
+
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+
+	return Vec2{v.X / length, v.Y / length}
+}
+
+// Perp returns the vector rotated a quarter turn counter-clockwise.
+func (v Vec2) Perp() Vec2 {
+
+	return Vec2{-v.Y, v.X}
+}
+
+// Rotate returns the vector rotated counter-clockwise by the angle, in
+// radians.
+func (v Vec2) Rotate(angle float64) Vec2 {
+
+	sin, cos := math.Sincos(angle)
+
+	return Vec2{(v.X * cos) - (v.Y * sin), (v.X * sin) + (v.Y * cos)}
+}
+
+// RotateAround returns the point rotated counter-clockwise by the angle, in
+// radians, around the origin point.
+func (v Vec2) RotateAround(origin Vec2, angle float64) Vec2 {
+
+	return v.Sub(origin).Rotate(angle).Add(origin)
+}
+
+// Bounds returns a Rect with no size at the point.
+func (v Vec2) Bounds() Rect {
+
+	return Rect{v, v}
+}
+
+// Contains returns true if the two points are the same.
+func (v Vec2) Contains(p Vec2) bool {
+
+	return v == p
+}
@@ -0,0 +1,444 @@
+package paunch
+
+import (
+	"math"
+)
+
+// Joint is a constraint that connects a Physics object to another Physics
+// object, or to a fixed point. Joints are solved every step, either by a World
+// alongside the contacts between its bodies, or by SolveJoints. Since Physics
+// objects never rotate, joints only hold their positions together. Physics
+// objects that belong to a RigidBody have the mass of the body, and other
+// Physics objects have a mass of 1.
+type Joint interface {
+	// PhysicsObjects returns the Physics objects that the Joint connects.
+	// The first object is never nil, and the second object is nil if the
+	// first object is connected to a fixed point.
+	PhysicsObjects() (*Physics, *Physics)
+
+	// applyForce pushes the objects toward where the Joint wants them
+	// before they are moved.
+	applyForce(dt float64)
+	// solveVelocity removes the velocity that would break the Joint.
+	solveVelocity()
+	// solvePosition moves the objects back to where the Joint holds them.
+	solvePosition()
+}
+
+// SolveJoints solves Joints between Physics objects that are not moved by a
+// World. It should be called every step, after the Step methods of the
+// Physics objects, with the same dt. Springs change the velocity of the
+// objects, and the other Joints remove the velocity that would break them and
+// move the objects back to where they are held.
+func SolveJoints(dt float64, joints ...Joint) {
+
+	if dt <= 0 {
+		return
+	}
+
+	for _, val := range joints {
+		val.applyForce(dt)
+	}
+	for i := 0; i < defaultIterations; i++ {
+		for _, val := range joints {
+			val.solveVelocity()
+		}
+	}
+	for i := 0; i < defaultIterations; i++ {
+		for _, val := range joints {
+			val.solvePosition()
+		}
+	}
+}
+
+// jointAnchor is a point that a Joint is attached to, which is either fixed
+// or moves with a Physics object.
+type jointAnchor struct {
+	physics *Physics
+	// x and y are relative to the position of the Physics object, or are
+	// the position of the point itself if it is fixed.
+	x, y float64
+}
+
+func newJointAnchor(physics *Physics, x, y float64) jointAnchor {
+
+	if physics == nil {
+		return jointAnchor{x: x, y: y}
+	}
+
+	physicsX, physicsY := physics.Position()
+
+	return jointAnchor{physics: physics, x: x - physicsX, y: y - physicsY}
+}
+
+// newJointAnchors creates the anchors of a Joint between the point x1, y1 on
+// the first Physics object and the point x2, y2 on the second. If only the
+// first object is nil, the objects and their points are swapped, so that the
+// first anchor always moves. False is returned if both objects are nil.
+func newJointAnchors(physics1 *Physics, x1, y1 float64, physics2 *Physics, x2, y2 float64) (jointAnchor, jointAnchor, bool) {
+
+	if physics1 == nil {
+		if physics2 == nil {
+			return jointAnchor{}, jointAnchor{}, false
+		}
+		physics1, x1, y1, physics2, x2, y2 = physics2, x2, y2, physics1, x1, y1
+	}
+
+	return newJointAnchor(physics1, x1, y1), newJointAnchor(physics2, x2, y2), true
+}
+
+func (anchor *jointAnchor) position() (float64, float64) {
+
+	if anchor.physics == nil {
+		return anchor.x, anchor.y
+	}
+
+	physicsX, physicsY := anchor.physics.Position()
+
+	return physicsX + anchor.x, physicsY + anchor.y
+}
+
+func (anchor *jointAnchor) velocity() (float64, float64) {
+
+	if anchor.physics == nil {
+		return 0, 0
+	}
+	if body := anchor.physics.rigidBody(); body != nil && body.bodyType == Static {
+		return 0, 0
+	}
+
+	return anchor.physics.velocity.x, anchor.physics.velocity.y
+}
+
+func (anchor *jointAnchor) inverseMass() float64 {
+
+	if anchor.physics == nil {
+		return 0
+	}
+
+	if body := anchor.physics.rigidBody(); body != nil {
+		return body.inverseMass()
+	}
+
+	return 1
+}
+
+// getJointAxis returns the unit vector from the first anchor to the second,
+// along with the distance between them. The axis is zero if the anchors are
+// in the same place.
+func getJointAxis(anchor1, anchor2 *jointAnchor) (float64, float64, float64) {
+
+	x1, y1 := anchor1.position()
+	x2, y2 := anchor2.position()
+
+	distance := math.Hypot(x2-x1, y2-y1)
+	if distance == 0 {
+		return 0, 0, 0
+	}
+
+	return (x2 - x1) / distance, (y2 - y1) / distance, distance
+}
+
+// applyJointImpulse changes the velocity of the Physics objects of the anchors
+// by the impulse, which pushes the second anchor and pulls the first.
+func applyJointImpulse(anchor1, anchor2 *jointAnchor, impulseX, impulseY float64) {
+
+	if invMass := anchor1.inverseMass(); invMass != 0 {
+		anchor1.physics.velocity.x -= impulseX * invMass
+		anchor1.physics.velocity.y -= impulseY * invMass
+	}
+	if invMass := anchor2.inverseMass(); invMass != 0 {
+		anchor2.physics.velocity.x += impulseX * invMass
+		anchor2.physics.velocity.y += impulseY * invMass
+	}
+}
+
+// moveJointAnchors moves the anchors apart by the specified distance,
+// moving the lighter object farther.
+func moveJointAnchors(anchor1, anchor2 *jointAnchor, x, y float64) {
+
+	invMass1, invMass2 := anchor1.inverseMass(), anchor2.inverseMass()
+	invMassSum := invMass1 + invMass2
+	if invMassSum == 0 {
+		return
+	}
+
+	if invMass1 != 0 {
+		anchor1.physics.Move(-x*invMass1/invMassSum, -y*invMass1/invMassSum)
+	}
+	if invMass2 != 0 {
+		anchor2.physics.Move(x*invMass2/invMassSum, y*invMass2/invMassSum)
+	}
+}
+
+// solveAxisVelocity removes the velocity of the anchors along the axis
+// between them. If pullOnly is true, only velocity that moves the anchors
+// apart is removed.
+func solveAxisVelocity(anchor1, anchor2 *jointAnchor, pullOnly bool) {
+
+	invMassSum := anchor1.inverseMass() + anchor2.inverseMass()
+	axisX, axisY, distance := getJointAxis(anchor1, anchor2)
+	if invMassSum == 0 || distance == 0 {
+		return
+	}
+
+	velX1, velY1 := anchor1.velocity()
+	velX2, velY2 := anchor2.velocity()
+	axisVel := ((velX2 - velX1) * axisX) + ((velY2 - velY1) * axisY)
+	if pullOnly && axisVel <= 0 {
+		return
+	}
+
+	impulse := -axisVel / invMassSum
+	applyJointImpulse(anchor1, anchor2, impulse*axisX, impulse*axisY)
+}
+
+// DistanceJoint keeps two anchor points at a fixed distance from each other,
+// like a rod. It is useful for chains and swinging platforms.
+type DistanceJoint struct {
+	anchor1, anchor2 jointAnchor
+	length           float64
+}
+
+// NewDistanceJoint creates a new DistanceJoint between the point x1, y1 on the
+// first Physics object and the point x2, y2 on the second, which keeps the
+// points at their current distance. The points are in world coordinates. If
+// either object is nil, the other object is connected to the fixed point
+// given for the nil one. Nil is returned if both objects are nil.
+func NewDistanceJoint(physics1 *Physics, x1, y1 float64, physics2 *Physics, x2, y2 float64) *DistanceJoint {
+
+	anchor1, anchor2, ok := newJointAnchors(physics1, x1, y1, physics2, x2, y2)
+	if !ok {
+		return nil
+	}
+
+	return &DistanceJoint{anchor1: anchor1, anchor2: anchor2, length: math.Hypot(x2-x1, y2-y1)}
+}
+
+// PhysicsObjects returns the Physics objects that the DistanceJoint connects.
+func (joint *DistanceJoint) PhysicsObjects() (*Physics, *Physics) {
+
+	return joint.anchor1.physics, joint.anchor2.physics
+}
+
+// SetLength sets the distance the DistanceJoint keeps between its points.
+func (joint *DistanceJoint) SetLength(length float64) {
+
+	joint.length = math.Max(length, 0)
+}
+
+// Length returns the distance the DistanceJoint keeps between its points.
+func (joint *DistanceJoint) Length() float64 {
+
+	return joint.length
+}
+
+func (joint *DistanceJoint) applyForce(dt float64) {}
+
+func (joint *DistanceJoint) solveVelocity() {
+
+	solveAxisVelocity(&joint.anchor1, &joint.anchor2, false)
+}
+
+func (joint *DistanceJoint) solvePosition() {
+
+	axisX, axisY, distance := getJointAxis(&joint.anchor1, &joint.anchor2)
+	if distance == 0 {
+		return
+	}
+
+	stretch := distance - joint.length
+	moveJointAnchors(&joint.anchor1, &joint.anchor2, -stretch*axisX, -stretch*axisY)
+}
+
+// RopeJoint keeps two anchor points from moving farther apart than a maximum
+// length, like a rope. The points may move closer together freely.
+type RopeJoint struct {
+	anchor1, anchor2 jointAnchor
+	maxLength        float64
+}
+
+// NewRopeJoint creates a new RopeJoint between the point x1, y1 on the first
+// Physics object and the point x2, y2 on the second, which keeps the points
+// within the maximum length of each other. The points are in world
+// coordinates. If either object is nil, the other object is connected to the
+// fixed point given for the nil one. Nil is returned if both objects are nil.
+func NewRopeJoint(physics1 *Physics, x1, y1 float64, physics2 *Physics, x2, y2, maxLength float64) *RopeJoint {
+
+	anchor1, anchor2, ok := newJointAnchors(physics1, x1, y1, physics2, x2, y2)
+	if !ok {
+		return nil
+	}
+
+	return &RopeJoint{anchor1: anchor1, anchor2: anchor2, maxLength: math.Max(maxLength, 0)}
+}
+
+// PhysicsObjects returns the Physics objects that the RopeJoint connects.
+func (joint *RopeJoint) PhysicsObjects() (*Physics, *Physics) {
+
+	return joint.anchor1.physics, joint.anchor2.physics
+}
+
+// SetMaxLength sets the farthest the RopeJoint lets its points move apart.
+func (joint *RopeJoint) SetMaxLength(maxLength float64) {
+
+	joint.maxLength = math.Max(maxLength, 0)
+}
+
+// MaxLength returns the farthest the RopeJoint lets its points move apart.
+func (joint *RopeJoint) MaxLength() float64 {
+
+	return joint.maxLength
+}
+
+func (joint *RopeJoint) applyForce(dt float64) {}
+
+func (joint *RopeJoint) solveVelocity() {
+
+	if _, _, distance := getJointAxis(&joint.anchor1, &joint.anchor2); distance < joint.maxLength {
+		return
+	}
+
+	solveAxisVelocity(&joint.anchor1, &joint.anchor2, true)
+}
+
+func (joint *RopeJoint) solvePosition() {
+
+	axisX, axisY, distance := getJointAxis(&joint.anchor1, &joint.anchor2)
+	if distance <= joint.maxLength {
+		return
+	}
+
+	stretch := distance - joint.maxLength
+	moveJointAnchors(&joint.anchor1, &joint.anchor2, -stretch*axisX, -stretch*axisY)
+}
+
+// SpringJoint pulls two anchor points toward a rest length from each other,
+// like a spring. Unlike a DistanceJoint, the points are free to stretch and
+// bounce.
+type SpringJoint struct {
+	anchor1, anchor2 jointAnchor
+	restLength       float64
+	stiffness        float64
+	damping          float64
+}
+
+// NewSpringJoint creates a new SpringJoint between the point x1, y1 on the
+// first Physics object and the point x2, y2 on the second, which rests at the
+// points' current distance. The points are in world coordinates. The
+// stiffness is the force of the spring for every unit it is stretched, and
+// the damping is the force that slows the spring for every unit per second it
+// stretches, which keeps the spring from bouncing forever. If either object is
+// nil, the other object is connected to the fixed point given for the nil
+// one. Nil is returned if both objects are nil.
+func NewSpringJoint(physics1 *Physics, x1, y1 float64, physics2 *Physics, x2, y2, stiffness, damping float64) *SpringJoint {
+
+	anchor1, anchor2, ok := newJointAnchors(physics1, x1, y1, physics2, x2, y2)
+	if !ok {
+		return nil
+	}
+
+	return &SpringJoint{anchor1: anchor1, anchor2: anchor2, restLength: math.Hypot(x2-x1, y2-y1),
+		stiffness: stiffness, damping: damping}
+}
+
+// PhysicsObjects returns the Physics objects that the SpringJoint connects.
+func (joint *SpringJoint) PhysicsObjects() (*Physics, *Physics) {
+
+	return joint.anchor1.physics, joint.anchor2.physics
+}
+
+// SetRestLength sets the distance between the points of the SpringJoint that
+// the spring pulls them toward.
+func (joint *SpringJoint) SetRestLength(restLength float64) {
+
+	joint.restLength = math.Max(restLength, 0)
+}
+
+// RestLength returns the distance between the points of the SpringJoint that
+// the spring pulls them toward.
+func (joint *SpringJoint) RestLength() float64 {
+
+	return joint.restLength
+}
+
+// SetStiffness sets the force of the SpringJoint for every unit it is
+// stretched.
+func (joint *SpringJoint) SetStiffness(stiffness float64) {
+
+	joint.stiffness = stiffness
+}
+
+// SetDamping sets the force that slows the SpringJoint for every unit per
+// second it stretches.
+func (joint *SpringJoint) SetDamping(damping float64) {
+
+	joint.damping = damping
+}
+
+func (joint *SpringJoint) applyForce(dt float64) {
+
+	axisX, axisY, distance := getJointAxis(&joint.anchor1, &joint.anchor2)
+	if distance == 0 {
+		return
+	}
+
+	velX1, velY1 := joint.anchor1.velocity()
+	velX2, velY2 := joint.anchor2.velocity()
+	axisVel := ((velX2 - velX1) * axisX) + ((velY2 - velY1) * axisY)
+
+	force := (-joint.stiffness * (distance - joint.restLength)) - (joint.damping * axisVel)
+	applyJointImpulse(&joint.anchor1, &joint.anchor2, force*dt*axisX, force*dt*axisY)
+}
+
+func (joint *SpringJoint) solveVelocity() {}
+
+func (joint *SpringJoint) solvePosition() {}
+
+// PinJoint holds a point on one Physics object to a point on another, or to a
+// fixed point, so that they move together as if pinned. Since Physics objects
+// never rotate, an object pinned to a fixed point is held in place.
+type PinJoint struct {
+	anchor1, anchor2 jointAnchor
+}
+
+// NewPinJoint creates a new PinJoint that pins the first Physics object to the
+// second at the point x, y, in world coordinates. If either object is nil, the
+// other object is pinned to the fixed point x, y. Nil is returned if both
+// objects are nil.
+func NewPinJoint(physics1, physics2 *Physics, x, y float64) *PinJoint {
+
+	anchor1, anchor2, ok := newJointAnchors(physics1, x, y, physics2, x, y)
+	if !ok {
+		return nil
+	}
+
+	return &PinJoint{anchor1: anchor1, anchor2: anchor2}
+}
+
+// PhysicsObjects returns the Physics objects that the PinJoint connects.
+func (joint *PinJoint) PhysicsObjects() (*Physics, *Physics) {
+
+	return joint.anchor1.physics, joint.anchor2.physics
+}
+
+func (joint *PinJoint) applyForce(dt float64) {}
+
+func (joint *PinJoint) solveVelocity() {
+
+	invMassSum := joint.anchor1.inverseMass() + joint.anchor2.inverseMass()
+	if invMassSum == 0 {
+		return
+	}
+
+	velX1, velY1 := joint.anchor1.velocity()
+	velX2, velY2 := joint.anchor2.velocity()
+	applyJointImpulse(&joint.anchor1, &joint.anchor2, -(velX2-velX1)/invMassSum, -(velY2-velY1)/invMassSum)
+}
+
+func (joint *PinJoint) solvePosition() {
+
+	x1, y1 := joint.anchor1.position()
+	x2, y2 := joint.anchor2.position()
+	moveJointAnchors(&joint.anchor1, &joint.anchor2, x1-x2, y1-y2)
+}
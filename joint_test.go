@@ -0,0 +1,64 @@
+package paunch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJointNilFirstObject(t *testing.T) {
+
+	world := NewWorld()
+	world.SetGravity(0, -100)
+
+	body := NewRigidBody(Dynamic, NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}))
+	world.Bodies = append(world.Bodies, body)
+
+	joint := NewRopeJoint(nil, 5, 40, body.Physics, 5, 10, 30)
+	if physics1, physics2 := joint.PhysicsObjects(); physics1 != body.Physics || physics2 != nil {
+		t.Fatalf("PhysicsObjects() = %p, %p, want %p, nil", physics1, physics2, body.Physics)
+	}
+	world.Joints = append(world.Joints, joint)
+
+	for i := 0; i < 60; i++ {
+		world.Step(1.0 / 60)
+	}
+
+	if _, y := body.Physics.Position(); y < -1 {
+		t.Errorf("body fell to a height of %v, want it held by the rope", y)
+	}
+
+	if NewPinJoint(nil, nil, 0, 0) != nil {
+		t.Error("NewPinJoint(nil, nil, 0, 0) is not nil")
+	}
+}
+
+func TestSolveJointsWithoutWorld(t *testing.T) {
+
+	bob := NewPhysics()
+	bob.Movers = append(bob.Movers, NewCollider([]float64{10, 0}))
+	other := NewPhysics()
+	other.Movers = append(other.Movers, NewCollider([]float64{20, 0}))
+
+	rope := NewRopeJoint(bob, 10, 0, nil, 0, 0, 10)
+	rod := NewDistanceJoint(bob, 10, 0, other, 20, 0)
+
+	for i := 0; i < 120; i++ {
+		bob.Accelerate(0, -100)
+		bob.Step(1.0 / 60)
+		other.Step(1.0 / 60)
+		SolveJoints(1.0/60, rope, rod)
+	}
+
+	x1, y1 := bob.Position()
+	if distance := math.Hypot(x1, y1); distance > 10+tolerance {
+		t.Errorf("bob is %v from the fixed point, want at most 10", distance)
+	}
+	if y1 > -1 {
+		t.Errorf("bob is at a height of %v, want it to have swung down", y1)
+	}
+
+	x2, y2 := other.Position()
+	if distance := math.Hypot(x2-x1, y2-y1); math.Abs(distance-10) > tolerance {
+		t.Errorf("the rod is %v long, want 10", distance)
+	}
+}
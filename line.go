@@ -13,6 +13,9 @@ type line struct {
 
 	m float64
 	b float64
+
+	tf *transform
+	collisionFilter
 }
 
 func newLine(start, end *point) *line {
@@ -44,18 +47,27 @@ func (l *line) Move(x, y float64) {
 	l.bounds.Move(x, y)
 
 	l.b = l.start.y - (l.m * l.start.x)
+
+	if l.tf != nil {
+		l.tf.anchor.Move(x, y)
+	}
 }
 
 func (l *line) SetPosition(x, y float64) {
 
-	xDisp := x - l.start.x
-	yDisp := y - l.start.y
+	posX, posY := l.Position()
+	xDisp := x - posX
+	yDisp := y - posY
 
 	l.Move(xDisp, yDisp)
 }
 
 func (l *line) Position() (x, y float64) {
 
+	if l.tf != nil {
+		return l.tf.anchor.x, l.tf.anchor.y
+	}
+
 	return l.start.x, l.start.y
 }
 
@@ -91,6 +103,52 @@ func (l *line) getPointFromY(y float64) (*point, error) {
 	return newPoint((y-l.b)/l.m, y), nil
 }
 
+func (l *line) getBounds() *bounding {
+
+	return l.bounds
+}
+
+// getClosestPoint returns the coordinates of the point on the line segment
+// that is closest to the given coordinates.
+func (l *line) getClosestPoint(x, y float64) (float64, float64) {
+
+	return getSegmentClosestPoint(l.start.x, l.start.y, l.end.x, l.end.y, x, y)
+}
+
+// getSegmentClosestPoint returns the coordinates of the point on the segment
+// between the first two points that is closest to the last point.
+func getSegmentClosestPoint(x1, y1, x2, y2, x, y float64) (float64, float64) {
+
+	xDist, yDist := x2-x1, y2-y1
+	lengthSquared := (xDist * xDist) + (yDist * yDist)
+	if lengthSquared == 0 {
+		return x1, y1
+	}
+
+	t := (((x - x1) * xDist) + ((y - y1) * yDist)) / lengthSquared
+	t = math.Max(0, math.Min(1, t))
+
+	return x1 + (t * xDist), y1 + (t * yDist)
+}
+
+// getSegmentDistanceSquared returns the square of the shortest distance
+// between the segment from a1 to a2 and the segment from b1 to b2.
+func getSegmentDistanceSquared(ax1, ay1, ax2, ay2, bx1, by1, bx2, by2 float64) float64 {
+
+	if segmentsIntersect(ax1, ay1, ax2, ay2, bx1, by1, bx2, by2) {
+		return 0
+	}
+
+	// Segments that do not cross are closest at one of their end points
+	distanceSquared := func(x1, y1, x2, y2, x, y float64) float64 {
+		closestX, closestY := getSegmentClosestPoint(x1, y1, x2, y2, x, y)
+		return ((x - closestX) * (x - closestX)) + ((y - closestY) * (y - closestY))
+	}
+
+	return math.Min(math.Min(distanceSquared(ax1, ay1, ax2, ay2, bx1, by1), distanceSquared(ax1, ay1, ax2, ay2, bx2, by2)),
+		math.Min(distanceSquared(bx1, by1, bx2, by2, ax1, ay1), distanceSquared(bx1, by1, bx2, by2, ax2, ay2)))
+}
+
 func (l *line) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
 
 	switch side {
@@ -111,88 +169,131 @@ func (l *line) DistanceToTangentPoint(x, y float64, side Direction) (float64, fl
 	}
 }
 
-func (b *bounding) getLines() []*line {
-
-	line := make([]*line, 4)
-
-	line[0] = newLine(newPoint(b.start.x, b.start.y), newPoint(b.end.x, b.start.y))
-	line[1] = newLine(newPoint(b.end.x, b.start.y), newPoint(b.end.x, b.end.y))
-	line[2] = newLine(newPoint(b.end.x, b.end.y), newPoint(b.start.x, b.end.y))
-	line[3] = newLine(newPoint(b.start.x, b.end.y), newPoint(b.start.x, b.start.y))
-
-	return line
+// getEdge returns the end points of one of the four edges of the bounding
+// box, in counter-clockwise order starting from the bottom edge.
+func (b *bounding) getEdge(i int) (x1, y1, x2, y2 float64) {
+
+	switch i {
+	case 0:
+		return b.start.x, b.start.y, b.end.x, b.start.y
+	case 1:
+		return b.end.x, b.start.y, b.end.x, b.end.y
+	case 2:
+		return b.end.x, b.end.y, b.start.x, b.end.y
+	default:
+		return b.start.x, b.end.y, b.start.x, b.start.y
+	}
 }
 
-func (l *line) onPoint(p *point) bool {
+// crossesSegment checks if any edge of the bounding box meets the segment
+// between the two points.
+func (b *bounding) crossesSegment(x1, y1, x2, y2 float64) bool {
 
-	if math.IsInf(l.m, 0) {
-		if p.y >= l.bounds.start.y && p.y <= l.bounds.end.y &&
-			math.Abs(p.x-l.start.x) < tolerance {
+	for i := 0; i < 4; i++ {
+		edgeX1, edgeY1, edgeX2, edgeY2 := b.getEdge(i)
+		if segmentsIntersect(x1, y1, x2, y2, edgeX1, edgeY1, edgeX2, edgeY2) {
 			return true
 		}
-
-		return false
 	}
 
-	if !p.onBounding(l.bounds) {
-		return false
-	}
+	return false
+}
 
-	if math.Abs(p.y-((l.m*p.x)+l.b)) < tolerance {
-		return true
+// getOrientation returns 1 if the third point is counter-clockwise from the
+// line through the first two, -1 if it is clockwise and 0 if the three points
+// are collinear.
+func getOrientation(x1, y1, x2, y2, x3, y3 float64) int {
+
+	cross := findDeterminate(x2-x1, y2-y1, x3-x1, y3-y1)
+	if cross > epsilon {
+		return 1
+	} else if cross < -epsilon {
+		return -1
 	}
 
-	return false
+	return 0
 }
 
-func (l *line) onBounding(b *bounding) bool {
+// withinSegmentBounds checks if a point collinear with a segment lies between
+// the segment's end points.
+func withinSegmentBounds(x1, y1, x2, y2, x, y float64) bool {
 
-	if !b.onBounding(l.bounds) {
-		return false
-	}
+	return x >= math.Min(x1, x2)-epsilon && x <= math.Max(x1, x2)+epsilon &&
+		y >= math.Min(y1, y2)-epsilon && y <= math.Max(y1, y2)+epsilon
+}
 
-	if l.start.onBounding(b) || l.end.onBounding(b) {
+// segmentsIntersect checks if the segment between the first two points meets
+// the segment between the last two points. Collinear segments intersect if
+// they overlap.
+func segmentsIntersect(ax1, ay1, ax2, ay2, bx1, by1, bx2, by2 float64) bool {
+
+	o1 := getOrientation(ax1, ay1, ax2, ay2, bx1, by1)
+	o2 := getOrientation(ax1, ay1, ax2, ay2, bx2, by2)
+	o3 := getOrientation(bx1, by1, bx2, by2, ax1, ay1)
+	o4 := getOrientation(bx1, by1, bx2, by2, ax2, ay2)
+
+	if o1 != o2 && o3 != o4 {
 		return true
 	}
 
-	boundlines := b.getLines()
-	for _, val := range boundlines {
-		if l.onLine(val) {
-			return true
-		}
+	// The remaining cases only intersect if an end point lies on the other
+	// segment
+	if o1 == 0 && withinSegmentBounds(ax1, ay1, ax2, ay2, bx1, by1) {
+		return true
+	}
+	if o2 == 0 && withinSegmentBounds(ax1, ay1, ax2, ay2, bx2, by2) {
+		return true
+	}
+	if o3 == 0 && withinSegmentBounds(bx1, by1, bx2, by2, ax1, ay1) {
+		return true
+	}
+	if o4 == 0 && withinSegmentBounds(bx1, by1, bx2, by2, ax2, ay2) {
+		return true
 	}
 
 	return false
 }
 
-func getLineIntersection(l1, l2 *line) *point {
+func (l *line) onPoint(p *point) bool {
+
+	return l.containsPoint(p.x, p.y)
+}
+
+// containsPoint checks if the coordinates lie on the line, within tolerance.
+func (l *line) containsPoint(x, y float64) bool {
 
-	if !l1.bounds.onBounding(l2.bounds) {
-		return nil
+	if math.IsInf(l.m, 0) {
+		if y >= l.bounds.start.y && y <= l.bounds.end.y &&
+			math.Abs(x-l.start.x) < tolerance {
+			return true
+		}
+
+		return false
 	}
 
-	if l1.m == l2.m {
-		return nil
+	if x < l.bounds.start.x || x > l.bounds.end.x ||
+		y < l.bounds.start.y || y > l.bounds.end.y {
+		return false
 	}
 
-	l1A := l1.end.y - l1.start.y
-	l1B := l1.start.x - l1.end.x
-	l1C := l1A*l1.start.x + l1B*l1.start.y
+	if math.Abs(y-((l.m*x)+l.b)) < tolerance {
+		return true
+	}
 
-	l2A := l2.end.y - l2.start.y
-	l2B := l2.start.x - l2.end.x
-	l2C := l2A*l2.start.x + l2B*l2.start.y
+	return false
+}
 
-	determinate := l1A*l2B - l2A*l1B
+func (l *line) onBounding(b *bounding) bool {
 
-	x := (l2B*l1C - l1B*l2C) / determinate
-	y := (l1A*l2C - l2A*l1C) / determinate
+	if !b.onBounding(l.bounds) {
+		return false
+	}
 
-	if x >= l1.start.x && x <= l1.end.x && y >= l1.start.y && y <= l1.end.y {
-		return newPoint(x, y)
+	if l.start.onBounding(b) || l.end.onBounding(b) {
+		return true
 	}
 
-	return nil
+	return b.crossesSegment(l.start.x, l.start.y, l.end.x, l.end.y)
 }
 
 func (l *line) onLine(l2 *line) bool {
@@ -201,11 +302,8 @@ func (l *line) onLine(l2 *line) bool {
 		return false
 	}
 
-	if intersection := getLineIntersection(l, l2); intersection != nil {
-		return true
-	}
-
-	return false
+	return segmentsIntersect(l.start.x, l.start.y, l.end.x, l.end.y,
+		l2.start.x, l2.start.y, l2.end.x, l2.end.y)
 }
 
 func (b *bounding) onLine(l *line) bool {
@@ -0,0 +1,341 @@
+package paunch
+
+import (
+	"math"
+)
+
+// ContactPoint is a point where two Collider objects touch.
+type ContactPoint struct {
+	X, Y float64
+}
+
+// Manifold describes how two overlapping Collider objects intersect. It can be
+// used to push the Collider objects apart along the shortest possible path.
+type Manifold struct {
+	// NormalX and NormalY make up the unit vector along which the Collider
+	// objects overlap the least. It points from the first Collider toward
+	// the second.
+	NormalX, NormalY float64
+	// Depth is the distance the Collider objects overlap along the normal.
+	// Moving the first Collider by -Normal * Depth, or the second by
+	// Normal * Depth, separates them.
+	Depth float64
+	// Contacts are the points where the Collider objects touch.
+	Contacts []ContactPoint
+}
+
+// convexShape is a convex set of points that is optionally rounded by a
+// radius. Every Collider can describe itself as a convexShape, which allows
+// for shape-independent separating axis tests.
+type convexShape struct {
+	points []point
+	radius float64
+}
+
+func (p *point) getConvex() *convexShape {
+
+	return &convexShape{points: []point{{x: p.x, y: p.y}}}
+}
+
+func (b *bounding) getConvex() *convexShape {
+
+	if b.oriented != nil {
+		return b.oriented.getConvex()
+	}
+
+	return &convexShape{points: []point{
+		{x: b.start.x, y: b.start.y},
+		{x: b.end.x, y: b.start.y},
+		{x: b.end.x, y: b.end.y},
+		{x: b.start.x, y: b.end.y}}}
+}
+
+func (l *line) getConvex() *convexShape {
+
+	return &convexShape{points: []point{{x: l.start.x, y: l.start.y}, {x: l.end.x, y: l.end.y}}}
+}
+
+func (poly *polygon) getConvex() *convexShape {
+
+	points := make([]point, len(poly.points))
+	for i, val := range poly.points {
+		points[i] = point{x: val.x, y: val.y}
+	}
+
+	return &convexShape{points: points}
+}
+
+func (c *circle) getConvex() *convexShape {
+
+	return &convexShape{points: []point{{x: c.center.x, y: c.center.y}}, radius: c.radius}
+}
+
+// project returns the minimum and maximum values of the shape projected onto
+// the given axis.
+func (shape *convexShape) project(axisX, axisY float64) (float64, float64) {
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, val := range shape.points {
+		dot := (val.x * axisX) + (val.y * axisY)
+		min = math.Min(min, dot)
+		max = math.Max(max, dot)
+	}
+
+	return min - shape.radius, max + shape.radius
+}
+
+// closestPoint returns the point on the outline of the shape, not including
+// the radius, closest to the given coordinates.
+func (shape *convexShape) closestPoint(x, y float64) point {
+
+	if len(shape.points) == 1 {
+		return shape.points[0]
+	}
+
+	closest := shape.points[0]
+	closestDist := math.Inf(1)
+	for i := range shape.points {
+		next := shape.points[(i+1)%len(shape.points)]
+		edge := line{start: &shape.points[i], end: &next}
+		candidateX, candidateY := edge.getClosestPoint(x, y)
+		xDist, yDist := x-candidateX, y-candidateY
+		if dist := (xDist * xDist) + (yDist * yDist); dist < closestDist {
+			closest = point{x: candidateX, y: candidateY}
+			closestDist = dist
+		}
+	}
+
+	return closest
+}
+
+// axes returns the candidate separating axes of the shape when it is tested
+// against the other shape.
+func (shape *convexShape) axes(other *convexShape) [][2]float64 {
+
+	var axes [][2]float64
+	addAxis := func(x, y float64) {
+		length := math.Hypot(x, y)
+		if length < 1e-12 {
+			return
+		}
+		axes = append(axes, [2]float64{x / length, y / length})
+	}
+
+	if len(shape.points) == 2 {
+		xDist, yDist := getPointDistance(&shape.points[0], &shape.points[1])
+		addAxis(-yDist, xDist)
+		addAxis(xDist, yDist)
+	} else if len(shape.points) > 2 {
+		for i := range shape.points {
+			next := shape.points[(i+1)%len(shape.points)]
+			xDist, yDist := getPointDistance(&shape.points[i], &next)
+			addAxis(-yDist, xDist)
+		}
+	}
+
+	if len(shape.points) == 1 || shape.radius > 0 {
+		for _, val := range shape.points {
+			closest := other.closestPoint(val.x, val.y)
+			addAxis(closest.x-val.x, closest.y-val.y)
+		}
+	}
+
+	return axes
+}
+
+// support returns the index of the point farthest along the given direction.
+func (shape *convexShape) support(dirX, dirY float64) int {
+
+	best := 0
+	bestDot := math.Inf(-1)
+	for i, val := range shape.points {
+		if dot := (val.x * dirX) + (val.y * dirY); dot > bestDot {
+			best = i
+			bestDot = dot
+		}
+	}
+
+	return best
+}
+
+// bestEdge returns the edge of the shape that is most perpendicular to the
+// given direction, along with the vertex farthest along that direction.
+func (shape *convexShape) bestEdge(dirX, dirY float64) (point, point, point) {
+
+	count := len(shape.points)
+	i := shape.support(dirX, dirY)
+	v := shape.points[i]
+	prev := shape.points[(i+count-1)%count]
+	next := shape.points[(i+1)%count]
+
+	lX, lY := v.x-next.x, v.y-next.y
+	rX, rY := v.x-prev.x, v.y-prev.y
+	lLen, rLen := math.Hypot(lX, lY), math.Hypot(rX, rY)
+	if lLen == 0 || (rLen != 0 && math.Abs((rX*dirX)+(rY*dirY))/rLen <= math.Abs((lX*dirX)+(lY*dirY))/lLen) {
+		return prev, v, v
+	}
+
+	return v, next, v
+}
+
+// clipSegment clips the segment to the portion where the dot product with the
+// given direction is at least the given offset.
+func clipSegment(v1, v2 point, dirX, dirY, offset float64) []point {
+
+	var clipped []point
+
+	d1 := (v1.x * dirX) + (v1.y * dirY) - offset
+	d2 := (v2.x * dirX) + (v2.y * dirY) - offset
+	if d1 >= 0 {
+		clipped = append(clipped, v1)
+	}
+	if d2 >= 0 {
+		clipped = append(clipped, v2)
+	}
+	if d1*d2 < 0 {
+		t := d1 / (d1 - d2)
+		clipped = append(clipped, point{x: v1.x + (t * (v2.x - v1.x)), y: v1.y + (t * (v2.y - v1.y))})
+	}
+
+	return clipped
+}
+
+// getContacts finds the contact points of two shapes that overlap along the
+// given normal, which points from the first shape to the second.
+func getContacts(shape1, shape2 *convexShape, normalX, normalY float64) []ContactPoint {
+
+	if len(shape1.points) == 1 {
+		return []ContactPoint{{shape1.points[0].x + (normalX * shape1.radius),
+			shape1.points[0].y + (normalY * shape1.radius)}}
+	}
+	if len(shape2.points) == 1 {
+		return []ContactPoint{{shape2.points[0].x - (normalX * shape2.radius),
+			shape2.points[0].y - (normalY * shape2.radius)}}
+	}
+
+	ref1, ref2, refMax := shape1.bestEdge(normalX, normalY)
+	inc1, inc2, _ := shape2.bestEdge(-normalX, -normalY)
+	refNormalX, refNormalY := normalX, normalY
+
+	refLen := math.Hypot(ref2.x-ref1.x, ref2.y-ref1.y)
+	incLen := math.Hypot(inc2.x-inc1.x, inc2.y-inc1.y)
+	if refLen == 0 || (incLen != 0 &&
+		math.Abs(((ref2.x-ref1.x)*normalX)+((ref2.y-ref1.y)*normalY))/refLen >
+			math.Abs(((inc2.x-inc1.x)*normalX)+((inc2.y-inc1.y)*normalY))/incLen) {
+		ref1, ref2, inc1, inc2 = inc1, inc2, ref1, ref2
+		refMax, _, _ = shape2.bestEdge(-normalX, -normalY)
+		refNormalX, refNormalY = -normalX, -normalY
+		refLen = incLen
+	}
+
+	if refLen == 0 {
+		return []ContactPoint{{refMax.x, refMax.y}}
+	}
+
+	dirX, dirY := (ref2.x-ref1.x)/refLen, (ref2.y-ref1.y)/refLen
+	clipped := clipSegment(inc1, inc2, dirX, dirY, (dirX*ref1.x)+(dirY*ref1.y))
+	if len(clipped) < 2 {
+		return []ContactPoint{{refMax.x, refMax.y}}
+	}
+	clipped = clipSegment(clipped[0], clipped[1], -dirX, -dirY, -((dirX * ref2.x) + (dirY * ref2.y)))
+	if len(clipped) < 2 {
+		return []ContactPoint{{refMax.x, refMax.y}}
+	}
+
+	faceX, faceY := -dirY, dirX
+	if (faceX*refNormalX)+(faceY*refNormalY) < 0 {
+		faceX, faceY = -faceX, -faceY
+	}
+	faceMax := (faceX * refMax.x) + (faceY * refMax.y)
+
+	var contacts []ContactPoint
+	for _, val := range clipped {
+		if (faceX*val.x)+(faceY*val.y)-faceMax <= tolerance {
+			contacts = append(contacts, ContactPoint{val.x, val.y})
+		}
+	}
+	if len(contacts) == 0 {
+		contacts = append(contacts, ContactPoint{refMax.x, refMax.y})
+	}
+
+	return contacts
+}
+
+// getManifold runs a separating axis test on the two shapes. The resulting
+// Manifold describes the axis of least overlap even if the shapes are
+// separated, in which case false is returned.
+func getManifold(shape1, shape2 *convexShape) (Manifold, bool) {
+
+	axes := append(shape1.axes(shape2), shape2.axes(shape1)...)
+	if len(axes) == 0 {
+		axes = [][2]float64{{1, 0}, {0, 1}}
+	}
+
+	var manifold Manifold
+	manifold.Depth = math.Inf(-1)
+	separated := false
+	best := math.Inf(1)
+
+	for _, axis := range axes {
+		min1, max1 := shape1.project(axis[0], axis[1])
+		min2, max2 := shape2.project(axis[0], axis[1])
+
+		overlap, normalX, normalY := max1-min2, axis[0], axis[1]
+		if max2-min1 < overlap {
+			overlap, normalX, normalY = max2-min1, -axis[0], -axis[1]
+		}
+
+		if overlap < 0 {
+			if !separated || overlap > manifold.Depth {
+				manifold.NormalX, manifold.NormalY, manifold.Depth = normalX, normalY, overlap
+			}
+			separated = true
+		} else if !separated && overlap < best {
+			best = overlap
+			manifold.NormalX, manifold.NormalY, manifold.Depth = normalX, normalY, overlap
+		}
+	}
+
+	if separated {
+		return manifold, false
+	}
+
+	manifold.Contacts = getContacts(shape1, shape2, manifold.NormalX, manifold.NormalY)
+
+	return manifold, true
+}
+
+// CollisionManifold checks if two Collider-satisfying objects are overlapping
+// and, if they are, returns a Manifold describing the overlap. Polygons are
+// treated as convex for the purposes of finding the normal and depth. The
+// Manifold of compound Colliders describes their deepest overlapping
+// children.
+func CollisionManifold(collider1, collider2 Collider) (Manifold, bool) {
+
+	if isGroup(collider1, collider2) {
+		var deepest Manifold
+		found := false
+		for _, val := range getCollidingChildren(collider1, collider2, true) {
+			manifold, ok := CollisionManifold(val[0], val[1])
+			if ok && (!found || manifold.Depth > deepest.Depth) {
+				deepest = manifold
+				found = true
+			}
+		}
+		return deepest, found
+	}
+
+	if !Collides(collider1, collider2) {
+		return Manifold{}, false
+	}
+
+	manifold, ok := getManifold(collider1.getConvex(), collider2.getConvex())
+	if !ok {
+		// The Colliders are only touching
+		manifold.Depth = 0
+		manifold.Contacts = getContacts(collider1.getConvex(), collider2.getConvex(),
+			manifold.NormalX, manifold.NormalY)
+	}
+
+	return manifold, true
+}
@@ -0,0 +1,75 @@
+package paunch
+
+import (
+	"image/png"
+	"os"
+)
+
+// NewMaskCollider creates a new Collider object that covers the pixels of the
+// supplied image data whose alpha value is at least the threshold. The data is
+// expected to be in the same RGBA format as the data given to NewSprite, so
+// the first row of pixels is the bottom row, and x, y is the position of the
+// bottom-left corner. The mask is a tile grid with one tile per pixel, which
+// can be scaled along with the Sprite it was made for. Nil is returned if the
+// data is too small for the specified width and height.
+func NewMaskCollider(x, y float64, width, height int, data []byte, threshold byte) Collider {
+
+	solid := getAlphaMask(width, height, data, threshold)
+	if solid == nil {
+		return nil
+	}
+
+	return NewTileGridCollider(x, y, 1, 1, solid)
+}
+
+// getAlphaMask returns which pixels of the RGBA data have an alpha value of
+// at least the threshold, row by row. Nil is returned if the data is too
+// small for the specified width and height.
+func getAlphaMask(width, height int, data []byte, threshold byte) [][]bool {
+
+	if width <= 0 || height <= 0 || len(data) < width*height*4 {
+		return nil
+	}
+
+	solid := make([][]bool, height)
+	for row := range solid {
+		solid[row] = make([]bool, width)
+		for column := range solid[row] {
+			solid[row][column] = data[(((row*width)+column)*4)+3] >= threshold
+		}
+	}
+
+	return solid
+}
+
+// NewMaskColliderFromImage creates a new mask Collider object from the given
+// PNG image file, with the bottom-left corner of the image at x, y.
+func NewMaskColliderFromImage(x, y float64, filename string, threshold byte) (Collider, error) {
+
+	width, height, data, err := loadImageBytes(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMaskCollider(x, y, width, height, data, threshold), nil
+}
+
+// loadImageBytes decodes the given PNG image file into RGBA data in the
+// format expected by NewSprite.
+func loadImageBytes(filename string) (int, int, []byte, error) {
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	width, height, data := imageToBytes(img)
+
+	return width, height, data, nil
+}
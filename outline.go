@@ -0,0 +1,135 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+)
+
+// outlineEdge is an edge between two pixels on the boundary of an opaque
+// region, directed so that the opaque pixel is on its left.
+type outlineEdge struct {
+	startX, startY int
+	endX, endY     int
+}
+
+// traceOutlines follows the boundaries between solid and empty pixels,
+// returning one counter-clockwise Polygon for every island of solid pixels
+// that touch along their sides. Holes in the islands are ignored.
+func traceOutlines(solid [][]bool) []geometry.Polygon {
+
+	isSolid := func(column, row int) bool {
+		return row >= 0 && row < len(solid) && column >= 0 && column < len(solid[row]) && solid[row][column]
+	}
+
+	outgoing := make(map[[2]int][]outlineEdge)
+	addEdge := func(startX, startY, endX, endY int) {
+		outgoing[[2]int{startX, startY}] = append(outgoing[[2]int{startX, startY}],
+			outlineEdge{startX, startY, endX, endY})
+	}
+
+	var starts []outlineEdge
+	for row := range solid {
+		for column := range solid[row] {
+			if !solid[row][column] {
+				continue
+			}
+
+			if !isSolid(column, row-1) {
+				addEdge(column, row, column+1, row)
+				starts = append(starts, outlineEdge{column, row, column + 1, row})
+			}
+			if !isSolid(column+1, row) {
+				addEdge(column+1, row, column+1, row+1)
+			}
+			if !isSolid(column, row+1) {
+				addEdge(column+1, row+1, column, row+1)
+			}
+			if !isSolid(column-1, row) {
+				addEdge(column, row+1, column, row)
+			}
+		}
+	}
+
+	used := make(map[outlineEdge]bool)
+	var outlines []geometry.Polygon
+
+	// Every loop has a bottom edge, so starting from them finds every loop
+	for _, start := range starts {
+		if used[start] {
+			continue
+		}
+
+		var points []geometry.Vec2
+		for edge := start; !used[edge]; {
+			used[edge] = true
+			points = append(points, geometry.Vec2{X: float64(edge.startX), Y: float64(edge.startY)})
+
+			// Where two islands meet at a corner, turning left keeps
+			// them apart
+			dirX, dirY := edge.endX-edge.startX, edge.endY-edge.startY
+			var next outlineEdge
+			bestTurn := -2
+			for _, val := range outgoing[[2]int{edge.endX, edge.endY}] {
+				nextDirX, nextDirY := val.endX-val.startX, val.endY-val.startY
+				turn := (dirX * nextDirY) - (dirY * nextDirX)
+				if turn > bestTurn && !used[val] {
+					next, bestTurn = val, turn
+				}
+			}
+			if bestTurn == -2 {
+				break
+			}
+			edge = next
+		}
+
+		if outline := (geometry.Polygon{Points: points}); outline.SignedArea() > 0 {
+			outlines = append(outlines, outline)
+		}
+	}
+
+	return outlines
+}
+
+// NewOutlineColliders creates polygon Collider objects that follow the
+// outline of the pixels of the supplied image data whose alpha value is at
+// least the threshold. The data is expected to be in the same RGBA format as
+// the data given to NewSprite, and x, y is the position of the bottom-left
+// corner of the image. One Collider is created for every separate island of
+// pixels, and holes are filled in. Outlines are simplified so that they
+// stray no more than the tolerance, in pixels, from the exact outline. Nil is
+// returned if the data is too small for the specified width and height.
+func NewOutlineColliders(x, y float64, width, height int, data []byte, threshold byte, tolerance float64) []Collider {
+
+	solid := getAlphaMask(width, height, data, threshold)
+	if solid == nil {
+		return nil
+	}
+
+	var colliders []Collider
+	for _, val := range traceOutlines(solid) {
+		outline := val.Simplify(tolerance)
+		if len(outline.Points) < 3 {
+			continue
+		}
+
+		for i := range outline.Points {
+			outline.Points[i].X += x
+			outline.Points[i].Y += y
+		}
+		colliders = append(colliders, NewColliderFromGeometry(outline))
+	}
+
+	return colliders
+}
+
+// NewOutlineCollidersFromImage creates polygon Collider objects that follow
+// the outline of the given PNG image file, with the bottom-left corner of the
+// image at x, y.
+func NewOutlineCollidersFromImage(x, y float64, filename string, threshold byte, tolerance float64) ([]Collider, error) {
+
+	width, height, data, err := loadImageBytes(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOutlineColliders(x, y, width, height, data, threshold, tolerance), nil
+}
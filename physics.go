@@ -13,6 +13,21 @@ type force struct {
 	active    bool
 }
 
+// Integrator is a method of moving a Physics object forward in time with the
+// Step method.
+type Integrator int
+
+const (
+	_ Integrator = iota
+	// SemiImplicitEuler updates the velocity before using it to move the
+	// Physics object. It is simple, fast and stable, and is the default.
+	SemiImplicitEuler
+	// VelocityVerlet moves the Physics object using both its velocity and
+	// its acceleration, so that objects under constant forces, like
+	// gravity, follow the same path no matter the size of the time step.
+	VelocityVerlet
+)
+
 // Physics is an object meant to make the Movement of multiple related Movers,
 // such as a Renderable and a Collision, easier. It also allows for easy
 // management of multiple forces of Movement at once.
@@ -24,24 +39,43 @@ type Physics struct {
 	minAccel physicsPoint
 	friction physicsPoint
 
-	usingMaxAccel map[Axis]bool
-	usingMinAccel map[Axis]bool
-	forces        map[string]force
+	velocity    physicsPoint
+	maxVelocity physicsPoint
+	minVelocity physicsPoint
+	integrator  Integrator
+
+	usingMaxAccel    map[Axis]bool
+	usingMinAccel    map[Axis]bool
+	usingMaxVelocity map[Axis]bool
+	usingMinVelocity map[Axis]bool
+	forces           map[string]force
+
+	sweepCollider Collider
+	sweepManager  *EventManager
+
+	// body is the RigidBody that the Physics object was created for, if
+	// any.
+	body *RigidBody
 }
 
+const sweepIterations = 3
+
 // NewPhysics creates a new Physics object.
 func NewPhysics() *Physics {
 
 	physics := &Physics{}
 	physics.usingMaxAccel = make(map[Axis]bool)
 	physics.usingMinAccel = make(map[Axis]bool)
+	physics.usingMaxVelocity = make(map[Axis]bool)
+	physics.usingMinVelocity = make(map[Axis]bool)
 	physics.forces = make(map[string]force)
 
 	return physics
 }
 
 // AddForce adds a constant force to the Physics object, which is taken
-// into account every time the Calculate method is called. The force is
+// into account every time the Calculate or Step method is called. With Step,
+// the force is an acceleration in units per second squared. The force is
 // disabled by default.
 func (physics *Physics) AddForce(name string, forceX, forceY float64) {
 
@@ -49,7 +83,7 @@ func (physics *Physics) AddForce(name string, forceX, forceY float64) {
 }
 
 // EnableForce makes the specified force active for future calls to the
-// Calculate method.
+// Calculate and Step methods.
 func (physics *Physics) EnableForce(name string) {
 
 	if _, ok := physics.forces[name]; ok {
@@ -58,7 +92,7 @@ func (physics *Physics) EnableForce(name string) {
 }
 
 // DisableForce makes the specified force inactive for future calls to the
-// Calculate method.
+// Calculate and Step methods.
 func (physics *Physics) DisableForce(name string) {
 
 	if _, ok := physics.forces[name]; ok {
@@ -114,7 +148,8 @@ func (physics *Physics) Acceleration() (float64, float64) {
 }
 
 // Accelerate exerts a specified force upon the Physics object the next time
-// the Calculate method is called.
+// the Calculate or Step method is called. With Step, the force is an
+// acceleration in units per second squared that lasts for a single step.
 func (physics *Physics) Accelerate(forceX, forceY float64) {
 
 	physics.accel.x += forceX
@@ -136,6 +171,8 @@ func (physics *Physics) SetAcceleration(force float64, axis Axis) {
 // SetMaxAcceleration sets the maximum allowed acceleration of the Physics
 // object on the specified axis. In situations where the object would normally
 // go faster than the specified value, it will be set to the value instead.
+// With Step, the value is in units per second squared, and with Calculate, it
+// is in units per call.
 func (physics *Physics) SetMaxAcceleration(force float64, axis Axis) {
 
 	switch axis {
@@ -151,6 +188,8 @@ func (physics *Physics) SetMaxAcceleration(force float64, axis Axis) {
 // SetMinAcceleration sets the minimum allowed acceleration of the Physics
 // object on the specified axis. In situations where the object would normally
 // go slower than the specified value, it will be set to the value instead.
+// With Step, the value is in units per second squared, and with Calculate, it
+// is in units per call.
 func (physics *Physics) SetMinAcceleration(force float64, axis Axis) {
 
 	switch axis {
@@ -166,14 +205,143 @@ func (physics *Physics) SetMinAcceleration(force float64, axis Axis) {
 // SetFriction sets the friction value of the Physics object. Friction is a
 // force that enfluences acceleration to Move toward zero. This might be used
 // to simulate the natural slowdown of an object rubbing against a surface.
+// With Step, friction slows the velocity in units per second squared.
 func (physics *Physics) SetFriction(forceX, forceY float64) {
 
 	physics.friction = physicsPoint{forceX, forceY}
 }
 
+// Velocity returns the X and Y velocity of the Physics object, in units per
+// second, as used by the Step method.
+func (physics *Physics) Velocity() (float64, float64) {
+
+	return physics.velocity.x, physics.velocity.y
+}
+
+// SetVelocity sets the velocity of the Physics object on the specified axis,
+// in units per second.
+func (physics *Physics) SetVelocity(velocity float64, axis Axis) {
+
+	switch axis {
+	case X:
+		physics.velocity.x = velocity
+	case Y:
+		physics.velocity.y = velocity
+	}
+}
+
+// SetMaxVelocity sets the maximum allowed velocity of the Physics object on
+// the specified axis, in units per second. In situations where the object
+// would normally go faster than the specified value, it will be set to the
+// value instead.
+func (physics *Physics) SetMaxVelocity(velocity float64, axis Axis) {
+
+	switch axis {
+	case X:
+		physics.maxVelocity.x = velocity
+	case Y:
+		physics.maxVelocity.y = velocity
+	}
+
+	physics.usingMaxVelocity[axis] = true
+}
+
+// SetMinVelocity sets the minimum allowed velocity of the Physics object on
+// the specified axis, in units per second. In situations where the object
+// would normally go slower than the specified value, it will be set to the
+// value instead.
+func (physics *Physics) SetMinVelocity(velocity float64, axis Axis) {
+
+	switch axis {
+	case X:
+		physics.minVelocity.x = velocity
+	case Y:
+		physics.minVelocity.y = velocity
+	}
+
+	physics.usingMinVelocity[axis] = true
+}
+
+// SetIntegrator sets the method the Step method uses to move the Physics
+// object. The default is SemiImplicitEuler.
+func (physics *Physics) SetIntegrator(integrator Integrator) {
+
+	physics.integrator = integrator
+}
+
+// EnableSweeping makes the Physics object check the path of the specified
+// Collider against the Colliders of the EventManager's objects when it is
+// moved by the Calculate or Step method. Movement stops at the first contact and
+// continues to slide along the surface, instead of passing through thin or
+// distant Colliders in a single jump. The Collider is usually one of the
+// Physics object's Movers.
+func (physics *Physics) EnableSweeping(collider Collider, eventManager *EventManager) {
+
+	physics.sweepCollider = collider
+	physics.sweepManager = eventManager
+}
+
+// DisableSweeping stops the Physics object from checking the path of its
+// Collider when moving.
+func (physics *Physics) DisableSweeping() {
+
+	physics.sweepCollider = nil
+	physics.sweepManager = nil
+}
+
+// moveMovers moves all the members of the Physics object, including the
+// swept Collider if it is not one of them.
+func (physics *Physics) moveMovers(x, y float64) {
+
+	sweepColliderMoved := physics.sweepCollider == nil
+	for _, val := range physics.Movers {
+		val.Move(x, y)
+		if !sweepColliderMoved && val == Mover(physics.sweepCollider) {
+			sweepColliderMoved = true
+		}
+	}
+
+	if !sweepColliderMoved {
+		physics.sweepCollider.Move(x, y)
+	}
+}
+
+// sweep moves the Physics object the specified distance, stopping and sliding
+// along any Colliders in the way if sweeping is enabled. Movement into any
+// surfaces that are struck is removed from the given velocity.
+func (physics *Physics) sweep(x, y float64, velocity *physicsPoint) {
+
+	if physics.sweepCollider == nil || physics.sweepManager == nil {
+		physics.moveMovers(x, y)
+		return
+	}
+
+	for i := 0; i < sweepIterations && (x != 0 || y != 0); i++ {
+		hit, ok := physics.sweepManager.Sweep(physics.sweepCollider, x, y)
+		if !ok {
+			physics.moveMovers(x, y)
+			return
+		}
+
+		physics.moveMovers(x*hit.Time, y*hit.Time)
+
+		x, y = x*(1-hit.Time), y*(1-hit.Time)
+		if dot := (x * hit.NormalX) + (y * hit.NormalY); dot < 0 {
+			x -= dot * hit.NormalX
+			y -= dot * hit.NormalY
+		}
+		if dot := (velocity.x * hit.NormalX) + (velocity.y * hit.NormalY); dot < 0 {
+			velocity.x -= dot * hit.NormalX
+			velocity.y -= dot * hit.NormalY
+		}
+	}
+}
+
 // Calculate Moves the Physics object given any specified constant forces,
 // calls to the Accelerate method, and any leftover acceleration. Then,
-// friction is applied to the resulting acceleration value.
+// friction is applied to the resulting acceleration value. Calculate treats
+// acceleration as the distance to move each call, so the speed of objects
+// depends on how often it is called. Step should be preferred for new code.
 func (physics *Physics) Calculate() {
 
 	for _, val := range physics.forces {
@@ -183,21 +351,9 @@ func (physics *Physics) Calculate() {
 		}
 	}
 
-	if physics.accel.x > physics.maxAccel.x && physics.usingMaxAccel[X] {
-		physics.accel.x = physics.maxAccel.x
-	} else if physics.accel.x < physics.minAccel.x && physics.usingMinAccel[X] {
-		physics.accel.x = physics.minAccel.x
-	}
+	physics.clampAcceleration(&physics.accel)
 
-	if physics.accel.y > physics.maxAccel.y && physics.usingMaxAccel[Y] {
-		physics.accel.y = physics.maxAccel.y
-	} else if physics.accel.y < physics.minAccel.y && physics.usingMinAccel[Y] {
-		physics.accel.y = physics.minAccel.y
-	}
-
-	for i := range physics.Movers {
-		physics.Movers[i].Move(physics.accel.x, physics.accel.y)
-	}
+	physics.sweep(physics.accel.x, physics.accel.y, &physics.accel)
 
 	if math.Abs(physics.accel.x) >= math.Abs(physics.friction.x) {
 		if physics.accel.x > 0 {
@@ -219,3 +375,114 @@ func (physics *Physics) Calculate() {
 		physics.accel.y = 0
 	}
 }
+
+// applyFriction moves the value toward zero by the amount of friction,
+// stopping at zero.
+func applyFriction(value, friction float64) float64 {
+
+	friction = math.Abs(friction)
+	if math.Abs(value) <= friction {
+		return 0
+	}
+	if value > 0 {
+		return value - friction
+	}
+
+	return value + friction
+}
+
+// rigidBody returns the RigidBody that the Physics object moves, or nil if it
+// does not belong to one.
+func (physics *Physics) rigidBody() *RigidBody {
+
+	if physics == nil || physics.body == nil || physics.body.Physics != physics {
+		return nil
+	}
+
+	return physics.body
+}
+
+// clampAcceleration keeps the given acceleration within the minimum and
+// maximum acceleration of the Physics object.
+func (physics *Physics) clampAcceleration(accel *physicsPoint) {
+
+	if accel.x > physics.maxAccel.x && physics.usingMaxAccel[X] {
+		accel.x = physics.maxAccel.x
+	} else if accel.x < physics.minAccel.x && physics.usingMinAccel[X] {
+		accel.x = physics.minAccel.x
+	}
+
+	if accel.y > physics.maxAccel.y && physics.usingMaxAccel[Y] {
+		accel.y = physics.maxAccel.y
+	} else if accel.y < physics.minAccel.y && physics.usingMinAccel[Y] {
+		accel.y = physics.minAccel.y
+	}
+}
+
+// clampVelocity keeps the velocity of the Physics object within its minimum
+// and maximum velocity.
+func (physics *Physics) clampVelocity() {
+
+	if physics.velocity.x > physics.maxVelocity.x && physics.usingMaxVelocity[X] {
+		physics.velocity.x = physics.maxVelocity.x
+	} else if physics.velocity.x < physics.minVelocity.x && physics.usingMinVelocity[X] {
+		physics.velocity.x = physics.minVelocity.x
+	}
+
+	if physics.velocity.y > physics.maxVelocity.y && physics.usingMaxVelocity[Y] {
+		physics.velocity.y = physics.maxVelocity.y
+	} else if physics.velocity.y < physics.minVelocity.y && physics.usingMinVelocity[Y] {
+		physics.velocity.y = physics.minVelocity.y
+	}
+}
+
+// Step moves the Physics object forward in time by dt seconds, using the
+// Integrator set with SetIntegrator. The acceleration of the Physics object
+// is the sum of its active constant forces and any calls to the Accelerate
+// method since the last step, in units per second squared, kept within the
+// minimum and maximum acceleration. It changes the velocity of the Physics
+// object, in units per second. Afterward, friction
+// slows the velocity, which is kept within the minimum and maximum velocity.
+// Unlike Calculate, acceleration does not carry over between steps.
+func (physics *Physics) Step(dt float64) {
+
+	if dt <= 0 {
+		return
+	}
+
+	accel := physics.accel
+	for _, val := range physics.forces {
+		if val.active {
+			accel.x += val.magnitude.x
+			accel.y += val.magnitude.y
+		}
+	}
+	physics.accel = physicsPoint{}
+	physics.clampAcceleration(&accel)
+
+	var moveX, moveY float64
+	switch physics.integrator {
+	case VelocityVerlet:
+		startX, startY := physics.velocity.x, physics.velocity.y
+		physics.velocity.x += accel.x * dt
+		physics.velocity.y += accel.y * dt
+		physics.clampVelocity()
+
+		// Average the velocity over the step, which is exact under
+		// constant acceleration
+		moveX = (startX + physics.velocity.x) * 0.5 * dt
+		moveY = (startY + physics.velocity.y) * 0.5 * dt
+	default:
+		physics.velocity.x += accel.x * dt
+		physics.velocity.y += accel.y * dt
+		physics.clampVelocity()
+
+		moveX, moveY = physics.velocity.x*dt, physics.velocity.y*dt
+	}
+
+	physics.sweep(moveX, moveY, &physics.velocity)
+
+	physics.velocity.x = applyFriction(physics.velocity.x, physics.friction.x*dt)
+	physics.velocity.y = applyFriction(physics.velocity.y, physics.friction.y*dt)
+	physics.clampVelocity()
+}
@@ -0,0 +1,20 @@
+package paunch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPhysicsStepClampsAcceleration(t *testing.T) {
+
+	physics := NewPhysics()
+	physics.SetMaxAcceleration(10, X)
+	physics.SetMinAcceleration(-20, Y)
+
+	physics.Accelerate(100, -100)
+	physics.Step(0.5)
+
+	if velX, velY := physics.Velocity(); math.Abs(velX-5) > epsilon || math.Abs(velY+10) > epsilon {
+		t.Errorf("Velocity() = %v, %v, want 5, -10", velX, velY)
+	}
+}
@@ -5,6 +5,9 @@ package paunch
 type point struct {
 	x float64
 	y float64
+
+	tf *transform
+	collisionFilter
 }
 
 func getPointDistance(point1, point2 *point) (float64, float64) {
@@ -23,21 +26,36 @@ func (p *point) Move(x, y float64) {
 
 	p.x += x
 	p.y += y
+
+	if p.tf != nil {
+		p.tf.anchor.x += x
+		p.tf.anchor.y += y
+	}
 }
 
 func (p *point) SetPosition(x, y float64) {
 
-	xDisp := x - p.x
-	yDisp := y - p.y
+	posX, posY := p.Position()
+	xDisp := x - posX
+	yDisp := y - posY
 
 	p.Move(xDisp, yDisp)
 }
 
 func (p *point) Position() (x, y float64) {
 
+	if p.tf != nil {
+		return p.tf.anchor.x, p.tf.anchor.y
+	}
+
 	return p.x, p.y
 }
 
+func (p *point) getBounds() *bounding {
+
+	return newBounding(p, p)
+}
+
 func (p *point) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
 
 	return getPointDistance(newPoint(x, y), p)
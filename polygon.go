@@ -7,19 +7,26 @@ import (
 // polygon is an object that represents a series of connected lines that form
 // a shape. It is meant to be used through the Collider interface.
 type polygon struct {
+	points []*point
 	lines  []*line
 	bounds *bounding
+
+	tf *transform
+	collisionFilter
 }
 
 func newPolygon(points []*point) *polygon {
 
 	var poly polygon
+	poly.points = make([]*point, len(points))
 	poly.lines = make([]*line, len(points))
 
 	min := newPoint(math.Inf(1), math.Inf(1))
 	max := newPoint(math.Inf(-1), math.Inf(-1))
 
 	for i := 0; i < len(points); i++ {
+		poly.points[i] = newPoint(points[i].x, points[i].y)
+
 		if i < len(points)-1 {
 			poly.lines[i] = newLine(points[i], points[i+1])
 		} else {
@@ -46,26 +53,43 @@ func newPolygon(points []*point) *polygon {
 
 func (poly *polygon) Move(x, y float64) {
 
+	for _, val := range poly.points {
+		val.Move(x, y)
+	}
 	for _, val := range poly.lines {
 		val.Move(x, y)
 	}
 
 	poly.bounds.Move(x, y)
+
+	if poly.tf != nil {
+		poly.tf.anchor.Move(x, y)
+	}
 }
 
 func (poly *polygon) SetPosition(x, y float64) {
 
-	xDisp := x - poly.lines[0].start.x
-	yDisp := y - poly.lines[0].start.y
+	posX, posY := poly.Position()
+	xDisp := x - posX
+	yDisp := y - posY
 
 	poly.Move(xDisp, yDisp)
 }
 
 func (poly *polygon) Position() (x, y float64) {
 
+	if poly.tf != nil {
+		return poly.tf.anchor.x, poly.tf.anchor.y
+	}
+
 	return poly.lines[0].start.x, poly.lines[0].start.y
 }
 
+func (poly *polygon) getBounds() *bounding {
+
+	return poly.bounds
+}
+
 func (poly *polygon) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
 
 	switch side {
@@ -156,53 +180,52 @@ func (poly *polygon) DistanceToTangentPoint(x, y float64, side Direction) (float
 
 func (poly *polygon) onPoint(p *point) bool {
 
-	if !p.onBounding(poly.bounds) {
+	return poly.containsPoint(p.x, p.y)
+}
+
+// containsPoint checks if the coordinates are inside or on the outline of the
+// polygon.
+func (poly *polygon) containsPoint(x, y float64) bool {
+
+	if x < poly.bounds.start.x || x > poly.bounds.end.x ||
+		y < poly.bounds.start.y || y > poly.bounds.end.y {
 		return false
 	}
 
-	ray := newLine(newPoint(math.Floor(p.x), math.Floor(p.y)),
-		newPoint(math.Floor(p.x+(poly.bounds.end.x-poly.bounds.start.x)), math.Floor(p.y)))
-
-	c := make(chan int, len(poly.lines))
-	intersects := 0
+	// Count the edges crossed by a ray cast to the right of the point. Each
+	// edge includes only its lower end point, so that rays passing through a
+	// vertex are counted correctly.
+	inside := false
 	for _, val := range poly.lines {
-		val := val
-		go func() {
-			intersectPnt := getLineIntersection(ray, val)
-			if intersectPnt == nil {
-				c <- 0
-				return
-			}
-
-			intersectCnt := 0
-			isOnVertex := false
-			if intersectPnt.onPoint(val.start) && val.end.y < intersectPnt.y {
-				intersectCnt++
-				isOnVertex = true
-			} else if intersectPnt.onPoint(val.end) && val.start.y < intersectPnt.y {
-				intersectCnt++
-				isOnVertex = true
-			} else if intersectPnt.onPoint(val.end) || intersectPnt.onPoint(val.start) {
-				isOnVertex = true
-			}
+		// Points on the outline are considered inside
+		if val.containsPoint(x, y) {
+			return true
+		}
 
-			if !isOnVertex {
-				intersectCnt++
-			}
+		if (val.start.y > y) == (val.end.y > y) {
+			continue
+		}
 
-			c <- intersectCnt
-		}()
+		crossX := val.start.x + ((y-val.start.y)/(val.end.y-val.start.y))*(val.end.x-val.start.x)
+		if crossX >= x {
+			inside = !inside
+		}
 	}
 
-	for i := 0; i < len(poly.lines); i++ {
-		intersects += <-c
-	}
+	return inside
+}
 
-	if intersects%2 == 0 {
-		return false
+// crossesSegment checks if any edge of the polygon meets the segment between
+// the two points.
+func (poly *polygon) crossesSegment(x1, y1, x2, y2 float64) bool {
+
+	for _, val := range poly.lines {
+		if segmentsIntersect(x1, y1, x2, y2, val.start.x, val.start.y, val.end.x, val.end.y) {
+			return true
+		}
 	}
 
-	return true
+	return false
 }
 
 func (poly *polygon) onBounding(b *bounding) bool {
@@ -211,15 +234,18 @@ func (poly *polygon) onBounding(b *bounding) bool {
 		return false
 	}
 
-	if poly.onPoint(b.start) || poly.onPoint(b.end) ||
-		poly.onPoint(newPoint(b.start.x, b.end.y)) ||
-		poly.onPoint(newPoint(b.end.x, b.start.y)) {
+	if poly.containsPoint(b.start.x, b.start.y) || poly.containsPoint(b.end.x, b.end.y) ||
+		poly.containsPoint(b.start.x, b.end.y) || poly.containsPoint(b.end.x, b.start.y) {
 		return true
 	}
 
-	boundLines := b.getLines()
-	for _, val := range boundLines {
-		if poly.onLine(val) {
+	// The polygon may be entirely inside the bounding box
+	if b.onPoint(poly.points[0]) {
+		return true
+	}
+
+	for _, val := range poly.lines {
+		if b.crossesSegment(val.start.x, val.start.y, val.end.x, val.end.y) {
 			return true
 		}
 	}
@@ -233,13 +259,12 @@ func (poly *polygon) onLine(l *line) bool {
 		return false
 	}
 
-	for _, val := range poly.lines {
-		if l.onLine(val) {
-			return true
-		}
+	// The line may be entirely inside the polygon
+	if poly.containsPoint(l.start.x, l.start.y) {
+		return true
 	}
 
-	return false
+	return poly.crossesSegment(l.start.x, l.start.y, l.end.x, l.end.y)
 }
 
 func (poly *polygon) onPolygon(poly2 *polygon) bool {
@@ -248,8 +273,13 @@ func (poly *polygon) onPolygon(poly2 *polygon) bool {
 		return false
 	}
 
+	// Either polygon may be entirely inside the other
+	if poly.onPoint(poly2.points[0]) || poly2.onPoint(poly.points[0]) {
+		return true
+	}
+
 	for _, val := range poly.lines {
-		if poly2.onLine(val) {
+		if poly2.crossesSegment(val.start.x, val.start.y, val.end.x, val.end.y) {
 			return true
 		}
 	}
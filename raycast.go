@@ -0,0 +1,248 @@
+package paunch
+
+import (
+	"math"
+)
+
+// RaycastHit describes where a ray struck a Collider.
+type RaycastHit struct {
+	// X and Y are the coordinates where the ray struck the Collider.
+	X, Y float64
+	// NormalX and NormalY make up the unit vector perpendicular to the
+	// surface that was struck, pointing back toward the ray's origin.
+	NormalX, NormalY float64
+	// Distance is the distance from the ray's origin to the hit.
+	Distance float64
+	// Collider is the Collider that was struck.
+	Collider Collider
+	// Object is the EventManager object that owns the Collider. It is nil
+	// for hits that did not come from an EventManager.
+	Object interface{}
+}
+
+// normalizeRay turns the direction of a ray into a unit vector, reporting
+// false if the direction has no length.
+func normalizeRay(dirX, dirY float64) (float64, float64, bool) {
+
+	length := math.Hypot(dirX, dirY)
+	if length == 0 {
+		return 0, 0, false
+	}
+
+	return dirX / length, dirY / length, true
+}
+
+// newRaycastHit creates a RaycastHit a distance along the ray with the
+// specified surface normal.
+func newRaycastHit(collider Collider, originX, originY, dirX, dirY, distance, normalX, normalY float64) RaycastHit {
+
+	return RaycastHit{X: originX + (dirX * distance), Y: originY + (dirY * distance),
+		NormalX: normalX, NormalY: normalY, Distance: distance, Collider: collider}
+}
+
+// raycastSegment finds the distance along the ray, which must have a unit
+// direction, at which it strikes the segment between the two points.
+func raycastSegment(originX, originY, dirX, dirY, startX, startY, endX, endY float64) (float64, float64, float64, bool) {
+
+	segX, segY := endX-startX, endY-startY
+	denominator := findDeterminate(dirX, dirY, segX, segY)
+	toStartX, toStartY := startX-originX, startY-originY
+
+	if denominator == 0 {
+		// Parallel lines only meet if they are collinear
+		if math.Abs(findDeterminate(toStartX, toStartY, dirX, dirY)) > tolerance {
+			return 0, 0, 0, false
+		}
+
+		tStart := (toStartX * dirX) + (toStartY * dirY)
+		tEnd := ((endX - originX) * dirX) + ((endY - originY) * dirY)
+		tMin, tMax := math.Min(tStart, tEnd), math.Max(tStart, tEnd)
+		if tMax < 0 {
+			return 0, 0, 0, false
+		}
+
+		return math.Max(0, tMin), -dirX, -dirY, true
+	}
+
+	t := findDeterminate(toStartX, toStartY, segX, segY) / denominator
+	u := findDeterminate(toStartX, toStartY, dirX, dirY) / denominator
+	if t < 0 || u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+
+	normalX, normalY, _ := normalizeRay(-segY, segX)
+	if (normalX*dirX)+(normalY*dirY) > 0 {
+		normalX, normalY = -normalX, -normalY
+	}
+
+	return t, normalX, normalY, true
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes the point, if within the
+// maximum distance.
+func (p *point) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	t := ((p.x - originX) * dirX) + ((p.y - originY) * dirY)
+	if t < 0 || t > maxDistance ||
+		math.Abs(findDeterminate(p.x-originX, p.y-originY, dirX, dirY)) > tolerance {
+		return RaycastHit{}, false
+	}
+
+	return newRaycastHit(p, originX, originY, dirX, dirY, t, -dirX, -dirY), true
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes the bounding box, if within
+// the maximum distance.
+func (b *bounding) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	if b.oriented != nil {
+		hit, ok := b.oriented.Raycast(originX, originY, dirX, dirY, maxDistance)
+		hit.Collider = b
+		return hit, ok
+	}
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	if b.onPoint(newPoint(originX, originY)) {
+		return newRaycastHit(b, originX, originY, dirX, dirY, 0, -dirX, -dirY), true
+	}
+
+	tMin, tMax := math.Inf(-1), math.Inf(1)
+	var normalX, normalY float64
+
+	if dirX == 0 {
+		if originX < b.start.x || originX > b.end.x {
+			return RaycastHit{}, false
+		}
+	} else {
+		t1, t2 := (b.start.x-originX)/dirX, (b.end.x-originX)/dirX
+		sideNormal := -1.0
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			sideNormal = 1
+		}
+		if t1 > tMin {
+			tMin, normalX, normalY = t1, sideNormal, 0
+		}
+		tMax = math.Min(tMax, t2)
+	}
+
+	if dirY == 0 {
+		if originY < b.start.y || originY > b.end.y {
+			return RaycastHit{}, false
+		}
+	} else {
+		t1, t2 := (b.start.y-originY)/dirY, (b.end.y-originY)/dirY
+		sideNormal := -1.0
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			sideNormal = 1
+		}
+		if t1 > tMin {
+			tMin, normalX, normalY = t1, 0, sideNormal
+		}
+		tMax = math.Min(tMax, t2)
+	}
+
+	if tMin > tMax || tMin < 0 || tMin > maxDistance {
+		return RaycastHit{}, false
+	}
+
+	return newRaycastHit(b, originX, originY, dirX, dirY, tMin, normalX, normalY), true
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes the line, if within the
+// maximum distance.
+func (l *line) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	t, normalX, normalY, ok := raycastSegment(originX, originY, dirX, dirY, l.start.x, l.start.y, l.end.x, l.end.y)
+	if !ok || t > maxDistance {
+		return RaycastHit{}, false
+	}
+
+	return newRaycastHit(l, originX, originY, dirX, dirY, t, normalX, normalY), true
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes the polygon, if within the
+// maximum distance.
+func (poly *polygon) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	if poly.onPoint(newPoint(originX, originY)) {
+		return newRaycastHit(poly, originX, originY, dirX, dirY, 0, -dirX, -dirY), true
+	}
+
+	hit := false
+	closest := maxDistance
+	var normalX, normalY float64
+	for _, val := range poly.lines {
+		t, edgeNormalX, edgeNormalY, ok := raycastSegment(originX, originY, dirX, dirY,
+			val.start.x, val.start.y, val.end.x, val.end.y)
+		if ok && t <= closest {
+			hit = true
+			closest, normalX, normalY = t, edgeNormalX, edgeNormalY
+		}
+	}
+
+	if !hit {
+		return RaycastHit{}, false
+	}
+
+	return newRaycastHit(poly, originX, originY, dirX, dirY, closest, normalX, normalY), true
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes the circle, if within the
+// maximum distance.
+func (c *circle) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	if c.onPoint(newPoint(originX, originY)) {
+		return newRaycastHit(c, originX, originY, dirX, dirY, 0, -dirX, -dirY), true
+	}
+
+	toCenterX, toCenterY := c.center.x-originX, c.center.y-originY
+	along := (toCenterX * dirX) + (toCenterY * dirY)
+	discriminant := (along * along) - ((toCenterX * toCenterX) + (toCenterY * toCenterY)) + (c.radius * c.radius)
+	if discriminant < 0 {
+		return RaycastHit{}, false
+	}
+
+	t := along - math.Sqrt(discriminant)
+	if t < 0 || t > maxDistance {
+		return RaycastHit{}, false
+	}
+
+	hit := newRaycastHit(c, originX, originY, dirX, dirY, t, 0, 0)
+	hit.NormalX, hit.NormalY, ok = normalizeRay(hit.X-c.center.x, hit.Y-c.center.y)
+	if !ok {
+		hit.NormalX, hit.NormalY = -dirX, -dirY
+	}
+
+	return hit, true
+}
@@ -0,0 +1,82 @@
+package paunch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEventManagerRaycast(t *testing.T) {
+
+	circle := newTestObject(NewCircleCollider(60, 5, 5))
+	wall := newTestObject(NewCollider([]float64{30, 0, 40, 0, 40, 10, 30, 10}))
+	triangle := newTestObject(NewCollider([]float64{10, 0, 15, 5, 10, 10}))
+	behind := newTestObject(NewCollider([]float64{-20, 0, -10, 0, -10, 10, -20, 10}))
+
+	eventManager := NewEventManager()
+	eventManager.Objects = append(eventManager.Objects, circle, wall, behind, triangle)
+
+	want := []struct {
+		object   interface{}
+		x        float64
+		distance float64
+	}{
+		{triangle, 10, 10},
+		{wall, 30, 30},
+		{circle, 55, 55},
+	}
+
+	// The direction does not need to be a unit vector
+	hits := eventManager.RaycastAll(0, 5, 3, 0, math.Inf(1))
+	if len(hits) != len(want) {
+		t.Fatalf("RaycastAll returned %d hits, want %d", len(hits), len(want))
+	}
+	for i, val := range want {
+		hit := hits[i]
+		if hit.Object != val.object || hit.Collider != val.object.(*testObject).colliders[0] {
+			t.Errorf("hit %d struck %T, want %T", i, hit.Collider, val.object.(*testObject).colliders[0])
+		}
+		if math.Abs(hit.X-val.x) > tolerance || math.Abs(hit.Y-5) > tolerance ||
+			math.Abs(hit.Distance-val.distance) > tolerance {
+			t.Errorf("hit %d is at %v, %v with a distance of %v, want %v, 5 with a distance of %v",
+				i, hit.X, hit.Y, hit.Distance, val.x, val.distance)
+		}
+		if math.Abs(hit.NormalX+1) > tolerance || math.Abs(hit.NormalY) > tolerance {
+			t.Errorf("hit %d has the normal %v, %v, want -1, 0", i, hit.NormalX, hit.NormalY)
+		}
+	}
+
+	hit, ok := eventManager.Raycast(0, 5, 1, 0, math.Inf(1))
+	if !ok || hit.Object != triangle {
+		t.Errorf("Raycast struck %v, %v, want the triangle", hit.Object, ok)
+	}
+
+	hits = eventManager.RaycastAll(0, 5, 1, 0, 35)
+	if len(hits) != 2 || hits[0].Object != triangle || hits[1].Object != wall {
+		t.Errorf("RaycastAll with a maximum distance of 35 returned %d hits, want the triangle and the wall", len(hits))
+	}
+
+	hit, ok = eventManager.Raycast(0, 5, -1, 0, math.Inf(1))
+	if !ok || hit.Object != behind || math.Abs(hit.Distance-10) > tolerance || math.Abs(hit.NormalX-1) > tolerance {
+		t.Errorf("Raycast backward struck %v at a distance of %v with a normal of %v, want the object behind",
+			hit.Object, hit.Distance, hit.NormalX)
+	}
+
+	misses := []struct {
+		name                               string
+		originX, originY, dirX, dirY, dist float64
+	}{
+		{"upward", 0, 5, 0, 1, math.Inf(1)},
+		{"above everything", 0, 20, 1, 0, math.Inf(1)},
+		{"too short", 0, 5, 1, 0, 9},
+		{"no direction", 0, 5, 0, 0, math.Inf(1)},
+	}
+
+	for _, test := range misses {
+		if hit, ok := eventManager.Raycast(test.originX, test.originY, test.dirX, test.dirY, test.dist); ok {
+			t.Errorf("%s: Raycast struck %T at %v, %v", test.name, hit.Collider, hit.X, hit.Y)
+		}
+		if hits := eventManager.RaycastAll(test.originX, test.originY, test.dirX, test.dirY, test.dist); len(hits) != 0 {
+			t.Errorf("%s: RaycastAll returned %d hits", test.name, len(hits))
+		}
+	}
+}
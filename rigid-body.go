@@ -0,0 +1,339 @@
+package paunch
+
+import (
+	"math"
+)
+
+// BodyType describes how a RigidBody reacts to forces and collisions.
+type BodyType int
+
+const (
+	_ BodyType = iota
+	// Dynamic bodies are moved by forces and pushed by collisions.
+	Dynamic
+	// Kinematic bodies move at their velocity, ignoring forces, and push
+	// dynamic bodies without being pushed back. They are useful for moving
+	// platforms.
+	Kinematic
+	// Static bodies never move, like walls and floors.
+	Static
+)
+
+const (
+	// correctionPercent is the portion of the overlap between two bodies
+	// that is corrected at once. Correcting less than the full overlap keeps
+	// stacked bodies from jittering.
+	correctionPercent = 0.8
+	// correctionSlop is the overlap allowed between two bodies before they
+	// are pushed apart, so that resting bodies stay in contact.
+	correctionSlop = 0.01
+)
+
+// RigidBody is a Physics object that reacts to collisions. When two bodies
+// collide, they are pushed apart and exchange momentum according to their
+// mass, restitution and friction. Bodies only move, they never rotate.
+type RigidBody struct {
+	// Physics moves the body and its Colliders. Other Movers, like the
+	// Sprite that represents the body, may be added to its Movers.
+	Physics *Physics
+	// Colliders are the shapes of the body.
+	Colliders []Collider
+
+	bodyType    BodyType
+	mass        float64
+	restitution float64
+	friction    float64
+
+	// sleeping bodies are not moved by a World until something wakes
+	// them. stillTime is how long the body has been moving slowly enough
+	// to fall asleep.
+	sleeping  bool
+	stillTime float64
+}
+
+// NewRigidBody creates a new RigidBody of the specified type with the given
+// Colliders as its shape, which are also the Movers of its Physics object. The
+// body has a mass of 1, no restitution and a friction coefficient of 0.2.
+func NewRigidBody(bodyType BodyType, colliders ...Collider) *RigidBody {
+
+	body := &RigidBody{Physics: NewPhysics(), Colliders: colliders,
+		bodyType: bodyType, mass: 1, friction: 0.2}
+	body.Physics.body = body
+
+	for _, val := range colliders {
+		body.Physics.Movers = append(body.Physics.Movers, val)
+	}
+
+	return body
+}
+
+// BodyType returns the type of the body.
+func (body *RigidBody) BodyType() BodyType {
+
+	return body.bodyType
+}
+
+// SetBodyType sets the type of the body.
+func (body *RigidBody) SetBodyType(bodyType BodyType) {
+
+	body.bodyType = bodyType
+}
+
+// SetMass sets the mass of the body. Heavier bodies push lighter bodies
+// farther. Values that are not positive are ignored.
+func (body *RigidBody) SetMass(mass float64) {
+
+	if mass > 0 {
+		body.mass = mass
+	}
+}
+
+// Mass returns the mass of the body. Static and kinematic bodies have an
+// infinite mass.
+func (body *RigidBody) Mass() float64 {
+
+	if body.bodyType != Dynamic {
+		return math.Inf(1)
+	}
+
+	return body.mass
+}
+
+// inverseMass returns the inverse of the body's mass, which is zero for
+// bodies that collisions cannot move.
+func (body *RigidBody) inverseMass() float64 {
+
+	if body.bodyType != Dynamic {
+		return 0
+	}
+
+	return 1 / body.mass
+}
+
+// SetRestitution sets how bouncy the body is. A restitution of 0 makes the
+// body stop when it collides, and a restitution of 1 makes it bounce back
+// with all of its speed. The bouncier of two colliding bodies is used.
+func (body *RigidBody) SetRestitution(restitution float64) {
+
+	body.restitution = math.Max(restitution, 0)
+}
+
+// Restitution returns how bouncy the body is.
+func (body *RigidBody) Restitution() float64 {
+
+	return body.restitution
+}
+
+// SetFriction sets the friction coefficient of the body, which slows bodies
+// sliding along it. A coefficient of 0 is perfectly slippery. Unlike the
+// friction of a Physics object, it only applies while bodies are touching.
+func (body *RigidBody) SetFriction(friction float64) {
+
+	body.friction = math.Max(friction, 0)
+}
+
+// Friction returns the friction coefficient of the body.
+func (body *RigidBody) Friction() float64 {
+
+	return body.friction
+}
+
+// ApplyImpulse instantly changes the momentum of the body, changing its
+// velocity by the impulse divided by its mass. Only dynamic bodies are
+// affected. The body is woken up if it is sleeping.
+func (body *RigidBody) ApplyImpulse(impulseX, impulseY float64) {
+
+	body.WakeUp()
+
+	invMass := body.inverseMass()
+	body.Physics.velocity.x += impulseX * invMass
+	body.Physics.velocity.y += impulseY * invMass
+}
+
+// Sleeping returns true if the body is sleeping. A World does not move
+// sleeping bodies until they are woken up.
+func (body *RigidBody) Sleeping() bool {
+
+	return body.sleeping
+}
+
+// WakeUp wakes the body up if it is sleeping. Bodies are woken up when
+// another moving body touches them, but must be woken up manually after their
+// velocity or forces are changed.
+func (body *RigidBody) WakeUp() {
+
+	body.sleeping = false
+	body.stillTime = 0
+}
+
+// Step moves the body forward in time by dt seconds. Dynamic bodies are
+// moved by their Physics object's Step method, kinematic bodies move at their
+// velocity and static bodies stay where they are.
+func (body *RigidBody) Step(dt float64) {
+
+	switch body.bodyType {
+	case Dynamic:
+		body.Physics.Step(dt)
+	case Kinematic:
+		if dt > 0 {
+			body.Physics.Move(body.Physics.velocity.x*dt, body.Physics.velocity.y*dt)
+		}
+	}
+}
+
+// contactConstraint stops two touching bodies from moving into each other
+// along the normal of their contact. It is solved several times per step, and
+// the impulses it applies are summed up across those times, so that it never
+// pulls the bodies together and friction never outgrows the total push.
+type contactConstraint struct {
+	body1, body2     *RigidBody
+	normalX, normalY float64
+	// bounce is the speed at which the bodies should separate along the
+	// normal, from their restitution.
+	bounce float64
+	// normalImpulse and tangentImpulse are the impulses applied so far along
+	// the normal and along the surface.
+	normalImpulse  float64
+	tangentImpulse float64
+}
+
+// newContactConstraint creates a new contactConstraint between two bodies for
+// the given Manifold, whose normal points from the first body toward the
+// second.
+func newContactConstraint(body1, body2 *RigidBody, manifold Manifold) *contactConstraint {
+
+	constraint := &contactConstraint{body1: body1, body2: body2,
+		normalX: manifold.NormalX, normalY: manifold.NormalY}
+
+	vel1, vel2 := body1.Physics.velocity, body2.Physics.velocity
+	normalVel := ((vel2.x - vel1.x) * manifold.NormalX) + ((vel2.y - vel1.y) * manifold.NormalY)
+	if normalVel < 0 {
+		constraint.bounce = -math.Max(body1.restitution, body2.restitution) * normalVel
+	}
+
+	return constraint
+}
+
+// applyImpulse pushes the second body along the specified direction and the
+// first body the opposite way.
+func (constraint *contactConstraint) applyImpulse(impulse, dirX, dirY float64) {
+
+	invMass1, invMass2 := constraint.body1.inverseMass(), constraint.body2.inverseMass()
+	vel1, vel2 := &constraint.body1.Physics.velocity, &constraint.body2.Physics.velocity
+
+	vel1.x -= impulse * dirX * invMass1
+	vel1.y -= impulse * dirY * invMass1
+	vel2.x += impulse * dirX * invMass2
+	vel2.y += impulse * dirY * invMass2
+}
+
+// warmStart applies the given impulses along the normal and along the
+// surface at once, as the starting point for solving the constraint. Using the
+// impulses of the same contact from the last step lets stacks of bodies settle
+// in few iterations.
+func (constraint *contactConstraint) warmStart(normalImpulse, tangentImpulse float64) {
+
+	constraint.normalImpulse, constraint.tangentImpulse = normalImpulse, tangentImpulse
+	constraint.applyImpulse(normalImpulse, constraint.normalX, constraint.normalY)
+	constraint.applyImpulse(tangentImpulse, -constraint.normalY, constraint.normalX)
+}
+
+// solve applies the impulses that bring the velocity of the bodies along the
+// normal closer to their bounce, including the impulse of friction along the
+// surface.
+func (constraint *contactConstraint) solve() {
+
+	invMassSum := constraint.body1.inverseMass() + constraint.body2.inverseMass()
+	if invMassSum == 0 {
+		return
+	}
+
+	vel1, vel2 := &constraint.body1.Physics.velocity, &constraint.body2.Physics.velocity
+	relX, relY := vel2.x-vel1.x, vel2.y-vel1.y
+	normalVel := (relX * constraint.normalX) + (relY * constraint.normalY)
+
+	// The total impulse along the normal may only ever push the bodies apart
+	impulse := (constraint.bounce - normalVel) / invMassSum
+	total := math.Max(constraint.normalImpulse+impulse, 0)
+	impulse, constraint.normalImpulse = total-constraint.normalImpulse, total
+	constraint.applyImpulse(impulse, constraint.normalX, constraint.normalY)
+
+	// Friction works against the sliding along the surface, but can never
+	// be stronger than the push along the normal
+	tangentX, tangentY := -constraint.normalY, constraint.normalX
+	relX, relY = vel2.x-vel1.x, vel2.y-vel1.y
+	tangentVel := (relX * tangentX) + (relY * tangentY)
+
+	friction := math.Sqrt(constraint.body1.friction*constraint.body2.friction) * constraint.normalImpulse
+	impulse = -tangentVel / invMassSum
+	total = math.Max(-friction, math.Min(constraint.tangentImpulse+impulse, friction))
+	impulse, constraint.tangentImpulse = total-constraint.tangentImpulse, total
+	constraint.applyImpulse(impulse, tangentX, tangentY)
+}
+
+// resolveVelocity applies the impulses that stop two bodies from moving into
+// each other along the normal of the Manifold, including the impulse of
+// friction along the surface. The normal points from the first body toward
+// the second.
+func resolveVelocity(body1, body2 *RigidBody, manifold Manifold) {
+
+	newContactConstraint(body1, body2, manifold).solve()
+}
+
+// correctPosition pushes two overlapping bodies apart along the normal of
+// the Manifold, moving the lighter body farther. The first body is moved by
+// -Normal * correction * its inverse mass and the second body by
+// Normal * correction * its inverse mass, where correction is returned.
+func correctPosition(body1, body2 *RigidBody, manifold Manifold) float64 {
+
+	invMass1, invMass2 := body1.inverseMass(), body2.inverseMass()
+	invMassSum := invMass1 + invMass2
+	if invMassSum == 0 {
+		return 0
+	}
+
+	correction := math.Max(manifold.Depth-correctionSlop, 0) / invMassSum * correctionPercent
+	if correction == 0 {
+		return 0
+	}
+	correctionX, correctionY := correction*manifold.NormalX, correction*manifold.NormalY
+
+	body1.Physics.Move(-correctionX*invMass1, -correctionY*invMass1)
+	body2.Physics.Move(correctionX*invMass2, correctionY*invMass2)
+
+	return correction
+}
+
+// ResolveCollision pushes two colliding bodies apart and exchanges momentum
+// between them, given the Manifold of the collision. The normal of the
+// Manifold points from the first body toward the second, as it does for the
+// Manifold of the first body's Collider and the second body's Collider. This
+// may be called from the OnCollisionManifold method of a body's owner.
+func ResolveCollision(body1, body2 *RigidBody, manifold Manifold) {
+
+	resolveVelocity(body1, body2, manifold)
+	correctPosition(body1, body2, manifold)
+}
+
+// Collide checks if any of the body's Colliders are colliding with any of the
+// other body's Colliders and, if they are, resolves each collision. Colliders
+// whose collision categories and masks do not match, and sensors, are
+// ignored. Collide returns true if the bodies were colliding.
+func (body *RigidBody) Collide(other *RigidBody) bool {
+
+	collided := false
+	for _, val1 := range body.Colliders {
+		for _, val2 := range other.Colliders {
+			if !canCollide(val1, val2) || !isResolvable(val1, val2) {
+				continue
+			}
+
+			if manifold, ok := CollisionManifold(val1, val2); ok {
+				ResolveCollision(body, other, manifold)
+				collided = true
+			}
+		}
+	}
+
+	return collided
+}
@@ -0,0 +1,148 @@
+package paunch
+
+import (
+	"time"
+)
+
+// defaultMaxUpdates is the number of ticks a Runner may run in a single frame
+// to catch up before it drops the time it is behind by.
+const defaultMaxUpdates = 5
+
+// Runner is a game loop that ticks EventManagers at a fixed rate, no matter
+// how quickly frames are drawn. Time is saved up between frames and spent on
+// as many ticks as fit into it, so that the game runs at the same speed on
+// fast and slow computers. Frames are drawn with the RunInterpolatedDrawEvent
+// method, so objects can be drawn smoothly between ticks.
+type Runner struct {
+	EventManagers []*EventManager
+
+	timestep    time.Duration
+	maxUpdates  int
+	frameTime   time.Duration
+	accumulator time.Duration
+}
+
+// NewRunner creates a new Runner that ticks the given EventManagers the
+// specified number of times per second. Nil is returned if the update rate is
+// not positive.
+func NewRunner(updateRate float64, eventManagers ...*EventManager) *Runner {
+
+	if updateRate <= 0 {
+		return nil
+	}
+
+	return &Runner{EventManagers: eventManagers,
+		timestep:   time.Duration(float64(time.Second) / updateRate),
+		maxUpdates: defaultMaxUpdates}
+}
+
+// Timestep returns the amount of time, in seconds, between ticks. It is the
+// value to give to Physics.Step in OnTick methods.
+func (runner *Runner) Timestep() float64 {
+
+	return runner.timestep.Seconds()
+}
+
+// SetMaxUpdates sets the number of ticks the Runner may run in a single frame
+// to catch up after falling behind. Once the limit is reached, the rest of the
+// time is dropped, so that the game slows down instead of spending every
+// frame catching up. The default is 5.
+func (runner *Runner) SetMaxUpdates(maxUpdates int) {
+
+	if maxUpdates < 1 {
+		maxUpdates = 1
+	}
+
+	runner.maxUpdates = maxUpdates
+}
+
+// SetFrameRate sets the maximum number of frames the Runner draws per second.
+// The Runner sleeps between frames to keep under the limit. A value of zero,
+// the default, draws frames as fast as possible.
+func (runner *Runner) SetFrameRate(frameRate float64) {
+
+	if frameRate <= 0 {
+		runner.frameTime = 0
+		return
+	}
+
+	runner.frameTime = time.Duration(float64(time.Second) / frameRate)
+}
+
+// advance adds the elapsed time to the Runner's saved up time and returns
+// the number of ticks to run, along with how far the Runner is between the
+// last tick and the next one, from 0 to 1.
+func (runner *Runner) advance(elapsed time.Duration) (int, float64) {
+
+	runner.accumulator += elapsed
+
+	updates := int(runner.accumulator / runner.timestep)
+	if updates > runner.maxUpdates {
+		updates = runner.maxUpdates
+		runner.accumulator = (runner.timestep * time.Duration(updates)) + (runner.accumulator % runner.timestep)
+	}
+	runner.accumulator -= runner.timestep * time.Duration(updates)
+
+	return updates, float64(runner.accumulator) / float64(runner.timestep)
+}
+
+// tick runs a tick event and a collision event on each of the Runner's
+// EventManagers.
+func (runner *Runner) tick() {
+
+	for _, val := range runner.EventManagers {
+		val.RunTickEvent()
+		val.RunCollisionEvent()
+	}
+}
+
+// draw clears the screen, runs a draw event on each of the Runner's
+// EventManagers and displays the result.
+func (runner *Runner) draw(alpha float64) error {
+
+	if err := Clear(); err != nil {
+		return err
+	}
+
+	for _, val := range runner.EventManagers {
+		val.RunInterpolatedDrawEvent(alpha)
+	}
+
+	return UpdateDisplay()
+}
+
+// Run runs the game loop until the user closes the window, or until an error
+// occurs. Every frame, events are updated once, so that input is handled even
+// on frames without any ticks, and the Runner runs as many ticks as the time
+// since the last frame allows, running a tick event and a collision event on
+// each EventManager for every tick. Then, the screen is cleared, the
+// EventManagers run an interpolated draw event and the display is updated.
+func (runner *Runner) Run() error {
+
+	last := time.Now()
+	runner.accumulator = 0
+
+	for !ShouldClose() {
+		frameStart := time.Now()
+		updates, alpha := runner.advance(frameStart.Sub(last))
+		last = frameStart
+
+		if err := UpdateEvents(); err != nil {
+			return err
+		}
+
+		for i := 0; i < updates; i++ {
+			runner.tick()
+		}
+
+		if err := runner.draw(alpha); err != nil {
+			return err
+		}
+
+		if runner.frameTime > 0 {
+			time.Sleep(runner.frameTime - time.Since(frameStart))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package paunch
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRunnerAdvance(t *testing.T) {
+
+	runner := NewRunner(100)
+	if runner.Timestep() != 0.01 {
+		t.Fatalf("Timestep() = %v, want 0.01", runner.Timestep())
+	}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		updates int
+		alpha   float64
+	}{
+		{"less than a tick", 4 * time.Millisecond, 0, 0.4},
+		{"finishing a tick", 8 * time.Millisecond, 1, 0.2},
+		{"several ticks", 31 * time.Millisecond, 3, 0.3},
+		{"exactly one tick", 10 * time.Millisecond, 1, 0.3},
+		// Falling behind runs the maximum number of ticks and drops the rest
+		// of the whole ticks, but keeps the time toward the next one
+		{"falling behind", 2005 * time.Millisecond, 5, 0.8},
+		{"after falling behind", 2 * time.Millisecond, 1, 0},
+		{"at the cap", 50 * time.Millisecond, 5, 0},
+		{"no time", 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		updates, alpha := runner.advance(test.elapsed)
+		if updates != test.updates || math.Abs(alpha-test.alpha) > tolerance {
+			t.Errorf("%s: advance(%v) = %v, %v, want %v, %v", test.name, test.elapsed,
+				updates, alpha, test.updates, test.alpha)
+		}
+	}
+
+	runner.SetMaxUpdates(0)
+	if updates, alpha := runner.advance(35 * time.Millisecond); updates != 1 || math.Abs(alpha-0.5) > tolerance {
+		t.Errorf("advance with a maximum of 0 updates = %v, %v, want 1, 0.5", updates, alpha)
+	}
+
+	if NewRunner(0) != nil {
+		t.Error("NewRunner(0) is not nil")
+	}
+}
@@ -0,0 +1,171 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+	"math"
+)
+
+// SweepHit describes when and where a moving Collider first touches another
+// Collider.
+type SweepHit struct {
+	// Time is the fraction of the displacement, from zero to one, that the
+	// moving Collider can travel before touching the other Collider.
+	Time float64
+	// NormalX and NormalY make up the unit vector perpendicular to the
+	// surface that was struck, pointing back toward the moving Collider.
+	NormalX, NormalY float64
+	// Collider is the Collider that was struck.
+	Collider Collider
+	// Object is the EventManager object that owns the Collider. It is nil
+	// for hits that did not come from an EventManager.
+	Object interface{}
+}
+
+// getConvexHull returns the convex hull of the given points in
+// counter-clockwise order.
+func getConvexHull(points []point) []point {
+
+	vecs := make([]geometry.Vec2, len(points))
+	for i, val := range points {
+		vecs[i] = geometry.Vec2{X: val.x, Y: val.y}
+	}
+
+	hull := geometry.ConvexHull(vecs)
+
+	hullPoints := make([]point, len(hull.Points))
+	for i, val := range hull.Points {
+		hullPoints[i] = point{x: val.X, y: val.Y}
+	}
+
+	return hullPoints
+}
+
+// raycastRoundedHull casts a ray from the origin, which must have a unit
+// direction, against a convex hull that is rounded by the given radius.
+func raycastRoundedHull(hull []point, radius, dirX, dirY, maxDistance float64) (float64, float64, float64, bool) {
+
+	hit := false
+	closest := maxDistance
+	var normalX, normalY float64
+
+	consider := func(t, candidateX, candidateY float64) {
+		if t <= closest {
+			hit = true
+			closest, normalX, normalY = t, candidateX, candidateY
+		}
+	}
+
+	if len(hull) == 1 || radius > 0 {
+		for _, val := range hull {
+			var h RaycastHit
+			var ok bool
+			if radius > 0 {
+				h, ok = newCircle(&val, radius).Raycast(0, 0, dirX, dirY, maxDistance)
+			} else {
+				h, ok = val.Raycast(0, 0, dirX, dirY, maxDistance)
+			}
+			if ok {
+				consider(h.Distance, h.NormalX, h.NormalY)
+			}
+		}
+	}
+
+	for i := range hull {
+		if len(hull) == 1 {
+			break
+		}
+
+		next := hull[(i+1)%len(hull)]
+		edgeX, edgeY := next.x-hull[i].x, next.y-hull[i].y
+		outwardX, outwardY, ok := normalizeRay(edgeY, -edgeX)
+		if !ok {
+			continue
+		}
+
+		offsetX, offsetY := outwardX*radius, outwardY*radius
+		t, _, _, ok := raycastSegment(0, 0, dirX, dirY, hull[i].x+offsetX, hull[i].y+offsetY,
+			next.x+offsetX, next.y+offsetY)
+		if ok && (outwardX*dirX)+(outwardY*dirY) <= 0 {
+			consider(t, outwardX, outwardY)
+		}
+	}
+
+	return closest, normalX, normalY, hit
+}
+
+// sweepConvex finds the time at which the first shape, moving the given
+// displacement, first touches the second shape.
+func sweepConvex(shape1, shape2 *convexShape, xDisp, yDisp float64) (float64, float64, float64, bool) {
+
+	dirX, dirY, ok := normalizeRay(xDisp, yDisp)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	length := math.Hypot(xDisp, yDisp)
+
+	// Shapes that already overlap only stop the movement if it would push
+	// them further together
+	if manifold, overlapping := getManifold(shape1, shape2); overlapping {
+		if (manifold.NormalX*dirX)+(manifold.NormalY*dirY) > 0 {
+			return 0, -manifold.NormalX, -manifold.NormalY, true
+		}
+		return 0, 0, 0, false
+	}
+
+	difference := make([]point, 0, len(shape1.points)*len(shape2.points))
+	for _, val1 := range shape1.points {
+		for _, val2 := range shape2.points {
+			difference = append(difference, point{x: val2.x - val1.x, y: val2.y - val1.y})
+		}
+	}
+
+	t, normalX, normalY, ok := raycastRoundedHull(getConvexHull(difference), shape1.radius+shape2.radius,
+		dirX, dirY, length)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	return t / length, normalX, normalY, true
+}
+
+// Sweep checks if the first Collider, if moved the specified distance, would
+// touch the second Collider along the way, and returns the SweepHit describing
+// the first moment of contact. Unlike moving the Collider and calling
+// Collides, Sweep does not miss Colliders that are passed over in a single
+// movement. Polygons are treated as convex. Compound Colliders are swept
+// child by child, and the Collider of the SweepHit is the child that was
+// struck. Tile grids are swept tile by tile.
+func Sweep(collider Collider, x, y float64, other Collider) (SweepHit, bool) {
+
+	if isGroup(collider, other) {
+		// Only the parts of the other Collider along the path matter
+		bounds := collider.getBounds()
+		path := newBounding(newPoint(bounds.start.x+math.Min(0, x), bounds.start.y+math.Min(0, y)),
+			newPoint(bounds.end.x+math.Max(0, x), bounds.end.y+math.Max(0, y)))
+
+		var first SweepHit
+		hit := false
+		for _, val1 := range getLeaves(collider, nil, true) {
+			for _, val2 := range getLeaves(other, path, false) {
+				// Groups that are not compound Colliders are reported as
+				// a whole
+				for _, val3 := range getLeaves(val2, path, true) {
+					sweepHit, ok := Sweep(val1, x, y, val3)
+					if ok && (!hit || sweepHit.Time < first.Time) {
+						sweepHit.Collider = val2
+						first = sweepHit
+						hit = true
+					}
+				}
+			}
+		}
+		return first, hit
+	}
+
+	t, normalX, normalY, ok := sweepConvex(collider.getConvex(), other.getConvex(), x, y)
+	if !ok {
+		return SweepHit{}, false
+	}
+
+	return SweepHit{Time: t, NormalX: normalX, NormalY: normalY, Collider: other}, true
+}
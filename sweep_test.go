@@ -0,0 +1,104 @@
+package paunch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSweep(t *testing.T) {
+
+	tests := []struct {
+		name             string
+		collider         Collider
+		x, y             float64
+		other            Collider
+		time             float64
+		normalX, normalY float64
+	}{
+		{"box into thin wall", NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}), 100, 0,
+			NewCollider([]float64{50, -20, 50, 30}), 0.4, -1, 0},
+		{"circle onto floor", NewCircleCollider(0, 0, 2), 0, -20,
+			NewCollider([]float64{-10, -10, 10, -10, 10, -8, -10, -8}), 0.3, 0, 1},
+		{"point into polygon", NewCollider([]float64{0, 5}), 40, 0,
+			NewCollider([]float64{20, 0, 30, 5, 20, 10}), 0.5, -1, 0},
+		{"capsule into box corner", NewCapsuleCollider(0, 0, 0, 10, 1), 20, 20,
+			NewCollider([]float64{11, 11, 20, 11, 20, 20, 11, 20}), 0.5, -1, 0},
+		{"overlapping box moving deeper", NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}), 5, 0,
+			NewCollider([]float64{8, 0, 18, 0, 18, 10, 8, 10}), 0, -1, 0},
+	}
+
+	for _, test := range tests {
+		hit, ok := Sweep(test.collider, test.x, test.y, test.other)
+		if !ok {
+			t.Errorf("%s: Sweep missed", test.name)
+			continue
+		}
+		if hit.Collider != test.other {
+			t.Errorf("%s: Sweep struck %T, want %T", test.name, hit.Collider, test.other)
+		}
+		if math.Abs(hit.Time-test.time) > tolerance {
+			t.Errorf("%s: Sweep hit at a time of %v, want %v", test.name, hit.Time, test.time)
+		}
+		if math.Abs(hit.NormalX-test.normalX) > tolerance || math.Abs(hit.NormalY-test.normalY) > tolerance {
+			t.Errorf("%s: Sweep hit with the normal %v, %v, want %v, %v", test.name,
+				hit.NormalX, hit.NormalY, test.normalX, test.normalY)
+		}
+	}
+
+	misses := []struct {
+		name     string
+		collider Collider
+		x, y     float64
+		other    Collider
+	}{
+		{"box short of wall", NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}), 30, 0,
+			NewCollider([]float64{50, -20, 50, 30})},
+		{"box passing over wall", NewCollider([]float64{0, 40, 10, 40, 10, 50, 0, 50}), 100, 0,
+			NewCollider([]float64{50, -20, 50, 30})},
+		{"overlapping box moving apart", NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}), -5, 0,
+			NewCollider([]float64{8, 0, 18, 0, 18, 10, 8, 10})},
+		{"no movement", NewCircleCollider(0, 0, 2), 0, 0, NewCircleCollider(10, 0, 2)},
+	}
+
+	for _, test := range misses {
+		if hit, ok := Sweep(test.collider, test.x, test.y, test.other); ok {
+			t.Errorf("%s: Sweep hit at a time of %v", test.name, hit.Time)
+		}
+	}
+}
+
+func TestSweepThinWall(t *testing.T) {
+
+	box := newTestObject(NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}))
+	near := newTestObject(NewCollider([]float64{50, -20, 50, 30}))
+	far := newTestObject(NewCollider([]float64{80, -20, 80, 30}))
+
+	eventManager := NewEventManager()
+	eventManager.Objects = append(eventManager.Objects, far, box, near)
+
+	// A single move of the whole distance skips over both walls
+	moved := NewCollider([]float64{100, 0, 110, 0, 110, 10, 100, 10})
+	if eventManager.Collides(moved) {
+		t.Fatal("the box collides with a wall after moving past it")
+	}
+
+	// The box's own Collider is ignored
+	hit, ok := eventManager.Sweep(box.colliders[0], 100, 0)
+	if !ok || hit.Object != near || math.Abs(hit.Time-0.4) > tolerance || math.Abs(hit.NormalX+1) > tolerance {
+		t.Errorf("Sweep struck %v at a time of %v with a normal of %v, want the near wall at 0.4",
+			hit.Object, hit.Time, hit.NormalX)
+	}
+
+	physics := NewPhysics()
+	physics.Movers = append(physics.Movers, box.colliders[0])
+	physics.EnableSweeping(box.colliders[0], eventManager)
+	physics.SetVelocity(6000, X)
+	physics.Step(1.0 / 60)
+
+	if x, _ := physics.Position(); math.Abs(x-40) > tolerance {
+		t.Errorf("the box stopped at %v, want it against the near wall at 40", x)
+	}
+	if velX, _ := physics.Velocity(); math.Abs(velX) > tolerance {
+		t.Errorf("the box is moving at %v after striking the wall, want 0", velX)
+	}
+}
@@ -0,0 +1,481 @@
+package paunch
+
+import (
+	"github.com/velovix/paunch/geometry"
+	"math"
+)
+
+// tileGrid is an object that represents a grid of solid and empty tiles. Solid
+// tiles are merged into as few bounding boxes as possible, so that shapes do
+// not snag on the edges between neighboring tiles. It is meant to be used
+// through the Collider interface.
+type tileGrid struct {
+	x, y          float64
+	tileWidth     float64
+	tileHeight    float64
+	rows, columns int
+	solid         [][]bool
+
+	// cells holds the index of the bounding box covering each tile, or -1
+	// for empty tiles, row by row.
+	cells  []int
+	rects  []*bounding
+	bounds *bounding
+
+	// rectStamps prevent a bounding box from being visited twice by a
+	// single query.
+	rectStamps []int
+	stamp      int
+
+	originX, originY float64
+	scaleX, scaleY   float64
+	collisionFilter
+}
+
+// NewTileGridCollider creates a new Collider object from a grid of tiles of
+// the specified size, with the bottom-left corner of the grid at x, y. Tiles
+// are solid where solid[row][column] is true, and the first row is the
+// bottom row. Only the tiles near a shape are inspected when testing for
+// collisions, which makes a single tile grid much faster than a Collider per
+// tile. Nil is returned if the tile size is not positive.
+func NewTileGridCollider(x, y, tileWidth, tileHeight float64, solid [][]bool) Collider {
+
+	if tileWidth <= 0 || tileHeight <= 0 {
+		return nil
+	}
+
+	grid := &tileGrid{x: x, y: y, tileWidth: tileWidth, tileHeight: tileHeight, scaleX: 1, scaleY: 1}
+
+	grid.rows = len(solid)
+	grid.solid = make([][]bool, len(solid))
+	for i, val := range solid {
+		grid.solid[i] = append([]bool{}, val...)
+		if len(val) > grid.columns {
+			grid.columns = len(val)
+		}
+	}
+
+	grid.mergeTiles()
+
+	return grid
+}
+
+func (grid *tileGrid) isSolid(row, column int) bool {
+
+	return column < len(grid.solid[row]) && grid.solid[row][column]
+}
+
+// mergeTiles covers the solid tiles with bounding boxes. Each box is grown as
+// far right as possible, and then as far up as the tiles allow.
+func (grid *tileGrid) mergeTiles() {
+
+	grid.cells = make([]int, grid.rows*grid.columns)
+	for i := range grid.cells {
+		grid.cells[i] = -1
+	}
+	grid.rects = nil
+
+	for row := 0; row < grid.rows; row++ {
+		for column := 0; column < grid.columns; column++ {
+			if !grid.isSolid(row, column) || grid.cells[(row*grid.columns)+column] != -1 {
+				continue
+			}
+
+			width := 1
+			for column+width < grid.columns && grid.isSolid(row, column+width) &&
+				grid.cells[(row*grid.columns)+column+width] == -1 {
+				width++
+			}
+
+			height := 1
+			for row+height < grid.rows {
+				filled := true
+				for i := column; i < column+width; i++ {
+					if !grid.isSolid(row+height, i) || grid.cells[((row+height)*grid.columns)+i] != -1 {
+						filled = false
+						break
+					}
+				}
+				if !filled {
+					break
+				}
+				height++
+			}
+
+			for i := row; i < row+height; i++ {
+				for j := column; j < column+width; j++ {
+					grid.cells[(i*grid.columns)+j] = len(grid.rects)
+				}
+			}
+			grid.rects = append(grid.rects, newBounding(
+				newPoint(float64(column), float64(row)),
+				newPoint(float64(column+width), float64(row+height))))
+		}
+	}
+
+	// The boxes are stored in tile units until now
+	for _, val := range grid.rects {
+		val.start.x, val.start.y = grid.x+(val.start.x*grid.tileWidth), grid.y+(val.start.y*grid.tileHeight)
+		val.end.x, val.end.y = grid.x+(val.end.x*grid.tileWidth), grid.y+(val.end.y*grid.tileHeight)
+	}
+
+	grid.rectStamps = make([]int, len(grid.rects))
+	grid.bounds = newBounding(newPoint(grid.x, grid.y),
+		newPoint(grid.x+(float64(grid.columns)*grid.tileWidth), grid.y+(float64(grid.rows)*grid.tileHeight)))
+}
+
+// getCellRange returns the range of tiles that overlap the given area,
+// reporting false if none do.
+func (grid *tileGrid) getCellRange(minX, minY, maxX, maxY float64) (int, int, int, int, bool) {
+
+	minColumn := int(math.Max(0, math.Floor((minX-grid.x)/grid.tileWidth)))
+	minRow := int(math.Max(0, math.Floor((minY-grid.y)/grid.tileHeight)))
+	maxColumn := int(math.Min(float64(grid.columns-1), math.Floor((maxX-grid.x)/grid.tileWidth)))
+	maxRow := int(math.Min(float64(grid.rows-1), math.Floor((maxY-grid.y)/grid.tileHeight)))
+
+	return minColumn, minRow, maxColumn, maxRow, minColumn <= maxColumn && minRow <= maxRow
+}
+
+// eachRect calls the callback once for every bounding box that covers a tile
+// overlapping the given area, stopping early if the callback returns true.
+func (grid *tileGrid) eachRect(minX, minY, maxX, maxY float64, callback func(*bounding) bool) bool {
+
+	minColumn, minRow, maxColumn, maxRow, ok := grid.getCellRange(minX, minY, maxX, maxY)
+	if !ok {
+		return false
+	}
+
+	grid.stamp++
+	for row := minRow; row <= maxRow; row++ {
+		for column := minColumn; column <= maxColumn; column++ {
+			index := grid.cells[(row*grid.columns)+column]
+			if index == -1 || grid.rectStamps[index] == grid.stamp {
+				continue
+			}
+			grid.rectStamps[index] = grid.stamp
+
+			if callback(grid.rects[index]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (grid *tileGrid) getChildren(bounds *bounding) []Collider {
+
+	var children []Collider
+	if bounds == nil {
+		for _, val := range grid.rects {
+			children = append(children, val)
+		}
+		return children
+	}
+
+	grid.eachRect(bounds.start.x, bounds.start.y, bounds.end.x, bounds.end.y, func(b *bounding) bool {
+		children = append(children, b)
+		return false
+	})
+
+	return children
+}
+
+func (grid *tileGrid) collidesWith(collider Collider) bool {
+
+	minX, minY, maxX, maxY := getBoundsValues(collider)
+
+	return grid.eachRect(minX, minY, maxX, maxY, func(b *bounding) bool {
+		return Collides(b, collider)
+	})
+}
+
+func (grid *tileGrid) onPoint(p *point) bool {
+
+	return grid.collidesWith(p)
+}
+
+func (grid *tileGrid) onBounding(b *bounding) bool {
+
+	return grid.collidesWith(b)
+}
+
+func (grid *tileGrid) onLine(l *line) bool {
+
+	return grid.collidesWith(l)
+}
+
+func (grid *tileGrid) onPolygon(poly *polygon) bool {
+
+	return grid.collidesWith(poly)
+}
+
+func (grid *tileGrid) onCircle(c *circle) bool {
+
+	return grid.collidesWith(c)
+}
+
+func (grid *tileGrid) onCapsule(c *capsule) bool {
+
+	return grid.collidesWith(c)
+}
+
+// getConvex returns the convex hull of the solid tiles.
+func (grid *tileGrid) getConvex() *convexShape {
+
+	points := make([]point, 0, len(grid.rects)*4)
+	for _, val := range grid.rects {
+		points = append(points, point{x: val.start.x, y: val.start.y},
+			point{x: val.end.x, y: val.start.y},
+			point{x: val.end.x, y: val.end.y},
+			point{x: val.start.x, y: val.end.y})
+	}
+
+	return &convexShape{points: getConvexHull(points)}
+}
+
+func (grid *tileGrid) getBounds() *bounding {
+
+	return grid.bounds
+}
+
+func (grid *tileGrid) Move(x, y float64) {
+
+	grid.x += x
+	grid.y += y
+
+	for _, val := range grid.rects {
+		val.Move(x, y)
+	}
+	grid.bounds.Move(x, y)
+}
+
+func (grid *tileGrid) SetPosition(x, y float64) {
+
+	posX, posY := grid.Position()
+	xDisp := x - posX
+	yDisp := y - posY
+
+	grid.Move(xDisp, yDisp)
+}
+
+// Position returns the x, y coordinates of the bottom-left corner of the tile
+// grid.
+func (grid *tileGrid) Position() (x, y float64) {
+
+	return grid.x, grid.y
+}
+
+// findTangentLine returns the index of the row or column nearest to the given
+// one that contains a solid tile, reporting false if there are none.
+func findTangentLine(count, index int, hasSolid func(int) bool) (int, bool) {
+
+	if index < 0 {
+		index = 0
+	} else if index >= count {
+		index = count - 1
+	}
+
+	for offset := 0; offset < count; offset++ {
+		if index-offset >= 0 && hasSolid(index-offset) {
+			return index - offset, true
+		}
+		if index+offset < count && hasSolid(index+offset) {
+			return index + offset, true
+		}
+	}
+
+	return 0, false
+}
+
+// DistanceToTangentPoint returns the distance to the farthest solid tile
+// toward the specified side in the column, or row, of the coordinates. If
+// that column or row is empty, the nearest one with a solid tile is used.
+func (grid *tileGrid) DistanceToTangentPoint(x, y float64, side Direction) (float64, float64) {
+
+	switch side {
+	case Up, Down:
+		column, ok := findTangentLine(grid.columns, int(math.Floor((x-grid.x)/grid.tileWidth)), func(column int) bool {
+			for row := 0; row < grid.rows; row++ {
+				if grid.isSolid(row, column) {
+					return true
+				}
+			}
+			return false
+		})
+		if !ok {
+			return 0, 0
+		}
+
+		sideX := math.Max(grid.x+(float64(column)*grid.tileWidth), math.Min(x, grid.x+(float64(column+1)*grid.tileWidth)))
+		for i := 0; i < grid.rows; i++ {
+			row := i
+			if side == Up {
+				row = grid.rows - 1 - i
+			}
+			if !grid.isSolid(row, column) {
+				continue
+			}
+
+			sideY := grid.y + (float64(row) * grid.tileHeight)
+			if side == Up {
+				sideY += grid.tileHeight
+			}
+			return getPointDistance(newPoint(x, y), newPoint(sideX, sideY))
+		}
+	case Left, Right:
+		row, ok := findTangentLine(grid.rows, int(math.Floor((y-grid.y)/grid.tileHeight)), func(row int) bool {
+			for column := 0; column < grid.columns; column++ {
+				if grid.isSolid(row, column) {
+					return true
+				}
+			}
+			return false
+		})
+		if !ok {
+			return 0, 0
+		}
+
+		sideY := math.Max(grid.y+(float64(row)*grid.tileHeight), math.Min(y, grid.y+(float64(row+1)*grid.tileHeight)))
+		for i := 0; i < grid.columns; i++ {
+			column := i
+			if side == Right {
+				column = grid.columns - 1 - i
+			}
+			if !grid.isSolid(row, column) {
+				continue
+			}
+
+			sideX := grid.x + (float64(column) * grid.tileWidth)
+			if side == Right {
+				sideX += grid.tileWidth
+			}
+			return getPointDistance(newPoint(x, y), newPoint(sideX, sideY))
+		}
+	}
+
+	return 0, 0
+}
+
+// Rotate has no effect, since tile grids are always axis-aligned.
+func (grid *tileGrid) Rotate(angle float64) {
+}
+
+// SetRotation has no effect, since tile grids are always axis-aligned.
+func (grid *tileGrid) SetRotation(angle float64) {
+}
+
+// Rotation always returns zero, since tile grids cannot be rotated.
+func (grid *tileGrid) Rotation() float64 {
+
+	return 0
+}
+
+// SetOrigin sets the point, relative to the tile grid's position, that
+// scaling happens around.
+func (grid *tileGrid) SetOrigin(x, y float64) {
+
+	grid.originX, grid.originY = x, y
+}
+
+// SetScale sets the scaling factor of the tile grid along the x and y axes,
+// which scales the size of its tiles. Tile grids cannot be flipped, so only
+// the magnitude of the scale is used. Scales of zero are ignored.
+func (grid *tileGrid) SetScale(x, y float64) {
+
+	x, y = math.Abs(x), math.Abs(y)
+	if x == 0 || y == 0 {
+		return
+	}
+
+	// Keep the origin in place while the tiles change size
+	grid.x -= grid.originX * ((x / grid.scaleX) - 1)
+	grid.y -= grid.originY * ((y / grid.scaleY) - 1)
+	grid.originX *= x / grid.scaleX
+	grid.originY *= y / grid.scaleY
+
+	grid.tileWidth *= x / grid.scaleX
+	grid.tileHeight *= y / grid.scaleY
+	grid.scaleX, grid.scaleY = x, y
+
+	grid.mergeTiles()
+}
+
+// Raycast casts a ray from the specified origin toward the specified
+// direction and returns where it first strikes a solid tile, if within the
+// maximum distance. Only the tiles along the ray are visited, in order, so
+// long rays over large grids stay cheap. The Collider of the RaycastHit is
+// the tile grid.
+func (grid *tileGrid) Raycast(originX, originY, dirX, dirY, maxDistance float64) (RaycastHit, bool) {
+
+	dirX, dirY, ok := normalizeRay(dirX, dirY)
+	if !ok || grid.rows == 0 || grid.columns == 0 {
+		return RaycastHit{}, false
+	}
+
+	// Start with the tile where the ray enters the grid
+	entry, ok := grid.bounds.Raycast(originX, originY, dirX, dirY, maxDistance)
+	if !ok {
+		return RaycastHit{}, false
+	}
+	column := int(math.Max(0, math.Min(float64(grid.columns-1), math.Floor((entry.X-grid.x)/grid.tileWidth))))
+	row := int(math.Max(0, math.Min(float64(grid.rows-1), math.Floor((entry.Y-grid.y)/grid.tileHeight))))
+
+	// nextX and nextY are the distances along the ray to the next column and
+	// row, and deltaX and deltaY are the distances between columns and rows
+	stepColumn, nextX, deltaX := 0, math.Inf(1), math.Inf(1)
+	if dirX > 0 {
+		stepColumn, deltaX = 1, grid.tileWidth/dirX
+		nextX = (grid.x + (float64(column+1) * grid.tileWidth) - originX) / dirX
+	} else if dirX < 0 {
+		stepColumn, deltaX = -1, -grid.tileWidth/dirX
+		nextX = (grid.x + (float64(column) * grid.tileWidth) - originX) / dirX
+	}
+	stepRow, nextY, deltaY := 0, math.Inf(1), math.Inf(1)
+	if dirY > 0 {
+		stepRow, deltaY = 1, grid.tileHeight/dirY
+		nextY = (grid.y + (float64(row+1) * grid.tileHeight) - originY) / dirY
+	} else if dirY < 0 {
+		stepRow, deltaY = -1, -grid.tileHeight/dirY
+		nextY = (grid.y + (float64(row) * grid.tileHeight) - originY) / dirY
+	}
+
+	for column >= 0 && column < grid.columns && row >= 0 && row < grid.rows {
+		// The ray enters the bounding box of the first solid tile it
+		// reaches within that tile, so the box gives the exact hit
+		if index := grid.cells[(row*grid.columns)+column]; index != -1 {
+			if hit, ok := grid.rects[index].Raycast(originX, originY, dirX, dirY, maxDistance); ok {
+				hit.Collider = grid
+				return hit, true
+			}
+		}
+
+		if nextX < nextY {
+			if nextX > maxDistance {
+				break
+			}
+			column += stepColumn
+			nextX += deltaX
+		} else {
+			if nextY > maxDistance {
+				break
+			}
+			row += stepRow
+			nextY += deltaY
+		}
+	}
+
+	return RaycastHit{}, false
+}
+
+// Geometry returns the shape of the tile grid as a geometry.Group of the Rects
+// that cover its solid tiles.
+func (grid *tileGrid) Geometry() geometry.Shape {
+
+	shapes := make([]geometry.Shape, len(grid.rects))
+	for i, val := range grid.rects {
+		shapes[i] = val.Geometry()
+	}
+
+	return geometry.Group{Shapes: shapes}
+}
@@ -0,0 +1,58 @@
+package paunch
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTileGridRaycast(t *testing.T) {
+
+	solid := [][]bool{
+		{true, true, true, true, true, true},
+		{true, false, false, false, false, true},
+		{true, false, true, true, false, true},
+		{true, false, false, false, false, false},
+		{true, true, false, true, true, true},
+	}
+	grid := NewTileGridCollider(-4, 2, 2, 1.5, solid).(*tileGrid)
+	grid.SetScale(1.5, 2)
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		originX, originY := (random.Float64()*30)-12, (random.Float64()*30)-5
+		angle := random.Float64() * 2 * math.Pi
+		dirX, dirY := math.Cos(angle), math.Sin(angle)
+		maxDistance := random.Float64() * 40
+
+		// Every bounding box is tested to find the expected hit
+		var want RaycastHit
+		wantOk := false
+		for _, val := range grid.rects {
+			if hit, ok := val.Raycast(originX, originY, dirX, dirY, maxDistance); ok &&
+				(!wantOk || hit.Distance < want.Distance) {
+				want, wantOk = hit, true
+			}
+		}
+
+		got, ok := grid.Raycast(originX, originY, dirX, dirY, maxDistance)
+		if ok != wantOk {
+			t.Fatalf("Raycast(%v, %v, %v, %v, %v) hit = %v, want %v", originX, originY, dirX, dirY, maxDistance, ok, wantOk)
+		}
+		if !ok {
+			if got != (RaycastHit{}) {
+				t.Fatalf("Raycast(%v, %v, %v, %v, %v) missed with %+v, want a zero RaycastHit",
+					originX, originY, dirX, dirY, maxDistance, got)
+			}
+			continue
+		}
+
+		if got.Collider != Collider(grid) {
+			t.Fatalf("Raycast(%v, %v, %v, %v, %v) hit %T, want the tile grid", originX, originY, dirX, dirY, maxDistance, got.Collider)
+		}
+		if math.Abs(got.Distance-want.Distance) > tolerance {
+			t.Fatalf("Raycast(%v, %v, %v, %v, %v) hit at %v, want %v",
+				originX, originY, dirX, dirY, maxDistance, got.Distance, want.Distance)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package paunch
+
+import (
+	"math"
+)
+
+// transform holds the rotation and scale of a Collider along with the shape
+// the Collider had before they were applied. Colliders only create a
+// transform once they are first rotated or scaled.
+type transform struct {
+	// anchor is the position of the Collider, which is unaffected by
+	// rotation and scaling.
+	anchor point
+	// originX and originY are the point, relative to the anchor, that
+	// rotation and scaling happen around.
+	originX, originY float64
+
+	rotation float64
+	scaleX   float64
+	scaleY   float64
+
+	// base holds the untransformed points of the Collider relative to the
+	// anchor.
+	base       []point
+	baseRadius float64
+}
+
+func newTransform(anchorX, anchorY float64, points []point, radius float64) *transform {
+
+	t := &transform{anchor: point{x: anchorX, y: anchorY}, scaleX: 1, scaleY: 1, baseRadius: radius}
+
+	t.base = make([]point, len(points))
+	for i, val := range points {
+		t.base[i] = point{x: val.x - anchorX, y: val.y - anchorY}
+	}
+
+	return t
+}
+
+// apply returns the transformed points of the Collider.
+func (t *transform) apply() []point {
+
+	sin, cos := math.Sincos(t.rotation)
+
+	points := make([]point, len(t.base))
+	for i, val := range t.base {
+		x := (val.x - t.originX) * t.scaleX
+		y := (val.y - t.originY) * t.scaleY
+
+		points[i].x = t.anchor.x + t.originX + (x * cos) - (y * sin)
+		points[i].y = t.anchor.y + t.originY + (x * sin) + (y * cos)
+	}
+
+	return points
+}
+
+// radius returns the transformed radius of a round Collider. Since circles
+// cannot be stretched, the larger of the two scales is used.
+func (t *transform) radius() float64 {
+
+	return t.baseRadius * math.Max(math.Abs(t.scaleX), math.Abs(t.scaleY))
+}
+
+// transformer is a Collider that can rotate and scale using a transform.
+type transformer interface {
+	getTransform() *transform
+	applyTransform()
+}
+
+func rotateCollider(c transformer, angle float64) {
+
+	c.getTransform().rotation += angle
+	c.applyTransform()
+}
+
+func setColliderRotation(c transformer, angle float64) {
+
+	c.getTransform().rotation = angle
+	c.applyTransform()
+}
+
+func setColliderOrigin(c transformer, x, y float64) {
+
+	t := c.getTransform()
+	t.originX, t.originY = x, y
+	c.applyTransform()
+}
+
+// rebaseColliderOrigin moves the origin of the Collider without changing its
+// current shape. The untransformed points are shifted instead, so that only
+// later rotation and scaling happen around the new origin.
+func rebaseColliderOrigin(c transformer, x, y float64) {
+
+	t := c.getTransform()
+	if t.scaleX == 0 || t.scaleY == 0 {
+		setColliderOrigin(c, x, y)
+		return
+	}
+
+	// Undo the rotation and scale of the distance the origin moves
+	diffX, diffY := t.originX-x, t.originY-y
+	sin, cos := math.Sincos(-t.rotation)
+	baseX := ((diffX * cos) - (diffY * sin)) / t.scaleX
+	baseY := ((diffX * sin) + (diffY * cos)) / t.scaleY
+
+	for i := range t.base {
+		t.base[i].x += baseX - diffX
+		t.base[i].y += baseY - diffY
+	}
+	t.originX, t.originY = x, y
+	c.applyTransform()
+}
+
+func setColliderScale(c transformer, x, y float64) {
+
+	t := c.getTransform()
+	t.scaleX, t.scaleY = x, y
+	c.applyTransform()
+}
+
+func (p *point) getTransform() *transform {
+
+	if p.tf == nil {
+		p.tf = newTransform(p.x, p.y, []point{{x: p.x, y: p.y}}, 0)
+	}
+
+	return p.tf
+}
+
+func (p *point) applyTransform() {
+
+	transformed := p.tf.apply()
+	p.x, p.y = transformed[0].x, transformed[0].y
+}
+
+// Rotate rotates the point by the specified angle, in radians, around its
+// origin.
+func (p *point) Rotate(angle float64) {
+
+	rotateCollider(p, angle)
+}
+
+// SetRotation sets the rotation of the point, in radians, around its origin.
+func (p *point) SetRotation(angle float64) {
+
+	setColliderRotation(p, angle)
+}
+
+// Rotation returns the rotation of the point in radians.
+func (p *point) Rotation() float64 {
+
+	if p.tf == nil {
+		return 0
+	}
+
+	return p.tf.rotation
+}
+
+// SetOrigin sets the point, relative to the point's position, that rotation
+// and scaling happen around.
+func (p *point) SetOrigin(x, y float64) {
+
+	setColliderOrigin(p, x, y)
+}
+
+// SetScale sets the scaling factor of the point along the x and y axes.
+func (p *point) SetScale(x, y float64) {
+
+	setColliderScale(p, x, y)
+}
+
+func (b *bounding) getTransform() *transform {
+
+	if b.tf == nil {
+		b.tf = newTransform(b.start.x, b.start.y, []point{
+			{x: b.start.x, y: b.start.y},
+			{x: b.end.x, y: b.start.y},
+			{x: b.end.x, y: b.end.y},
+			{x: b.start.x, y: b.end.y}}, 0)
+	}
+
+	return b.tf
+}
+
+// applyTransform updates the bounding box with its transformed corners. A
+// rotated bounding box is no longer axis-aligned, so it is backed by an
+// oriented polygon until its rotation is set back to zero.
+func (b *bounding) applyTransform() {
+
+	corners := b.tf.apply()
+
+	min := newPoint(math.Inf(1), math.Inf(1))
+	max := newPoint(math.Inf(-1), math.Inf(-1))
+	for _, val := range corners {
+		min.x, min.y = math.Min(min.x, val.x), math.Min(min.y, val.y)
+		max.x, max.y = math.Max(max.x, val.x), math.Max(max.y, val.y)
+	}
+	b.start, b.end = min, max
+
+	if math.Mod(b.tf.rotation, 2*math.Pi) == 0 {
+		b.oriented = nil
+		return
+	}
+
+	points := make([]*point, len(corners))
+	for i := range corners {
+		points[i] = &corners[i]
+	}
+	b.oriented = newPolygon(points)
+}
+
+// Rotate rotates the bounding box by the specified angle, in radians, around
+// its origin. A rotated bounding box behaves like a polygon.
+func (b *bounding) Rotate(angle float64) {
+
+	rotateCollider(b, angle)
+}
+
+// SetRotation sets the rotation of the bounding box, in radians, around its
+// origin. A rotated bounding box behaves like a polygon.
+func (b *bounding) SetRotation(angle float64) {
+
+	setColliderRotation(b, angle)
+}
+
+// Rotation returns the rotation of the bounding box in radians.
+func (b *bounding) Rotation() float64 {
+
+	if b.tf == nil {
+		return 0
+	}
+
+	return b.tf.rotation
+}
+
+// SetOrigin sets the point, relative to the bounding box's position, that
+// rotation and scaling happen around.
+func (b *bounding) SetOrigin(x, y float64) {
+
+	setColliderOrigin(b, x, y)
+}
+
+// SetScale sets the scaling factor of the bounding box along the x and y
+// axes.
+func (b *bounding) SetScale(x, y float64) {
+
+	setColliderScale(b, x, y)
+}
+
+func (l *line) getTransform() *transform {
+
+	if l.tf == nil {
+		l.tf = newTransform(l.start.x, l.start.y, []point{
+			{x: l.start.x, y: l.start.y},
+			{x: l.end.x, y: l.end.y}}, 0)
+	}
+
+	return l.tf
+}
+
+// applyTransform updates the line with its transformed end points, along with
+// its precomputed slope, intercept and bounds.
+func (l *line) applyTransform() {
+
+	ends := l.tf.apply()
+	transformed := newLine(&ends[0], &ends[1])
+
+	l.start, l.end, l.bounds = transformed.start, transformed.end, transformed.bounds
+	l.m, l.b = transformed.m, transformed.b
+}
+
+// Rotate rotates the line by the specified angle, in radians, around its
+// origin.
+func (l *line) Rotate(angle float64) {
+
+	rotateCollider(l, angle)
+}
+
+// SetRotation sets the rotation of the line, in radians, around its origin.
+func (l *line) SetRotation(angle float64) {
+
+	setColliderRotation(l, angle)
+}
+
+// Rotation returns the rotation of the line in radians.
+func (l *line) Rotation() float64 {
+
+	if l.tf == nil {
+		return 0
+	}
+
+	return l.tf.rotation
+}
+
+// SetOrigin sets the point, relative to the line's position, that rotation
+// and scaling happen around.
+func (l *line) SetOrigin(x, y float64) {
+
+	setColliderOrigin(l, x, y)
+}
+
+// SetScale sets the scaling factor of the line along the x and y axes.
+func (l *line) SetScale(x, y float64) {
+
+	setColliderScale(l, x, y)
+}
+
+func (poly *polygon) getTransform() *transform {
+
+	if poly.tf == nil {
+		points := make([]point, len(poly.points))
+		for i, val := range poly.points {
+			points[i] = point{x: val.x, y: val.y}
+		}
+		anchorX, anchorY := poly.Position()
+		poly.tf = newTransform(anchorX, anchorY, points, 0)
+	}
+
+	return poly.tf
+}
+
+// applyTransform updates the polygon with its transformed points, along with
+// the precomputed slopes of its lines and its bounds.
+func (poly *polygon) applyTransform() {
+
+	points := poly.tf.apply()
+	pointers := make([]*point, len(points))
+	for i := range points {
+		pointers[i] = &points[i]
+	}
+	transformed := newPolygon(pointers)
+
+	poly.points, poly.lines, poly.bounds = transformed.points, transformed.lines, transformed.bounds
+}
+
+// Rotate rotates the polygon by the specified angle, in radians, around its
+// origin.
+func (poly *polygon) Rotate(angle float64) {
+
+	rotateCollider(poly, angle)
+}
+
+// SetRotation sets the rotation of the polygon, in radians, around its
+// origin.
+func (poly *polygon) SetRotation(angle float64) {
+
+	setColliderRotation(poly, angle)
+}
+
+// Rotation returns the rotation of the polygon in radians.
+func (poly *polygon) Rotation() float64 {
+
+	if poly.tf == nil {
+		return 0
+	}
+
+	return poly.tf.rotation
+}
+
+// SetOrigin sets the point, relative to the polygon's position, that rotation
+// and scaling happen around.
+func (poly *polygon) SetOrigin(x, y float64) {
+
+	setColliderOrigin(poly, x, y)
+}
+
+// SetScale sets the scaling factor of the polygon along the x and y axes.
+func (poly *polygon) SetScale(x, y float64) {
+
+	setColliderScale(poly, x, y)
+}
+
+func (c *circle) getTransform() *transform {
+
+	if c.tf == nil {
+		c.tf = newTransform(c.center.x, c.center.y, []point{{x: c.center.x, y: c.center.y}}, c.radius)
+	}
+
+	return c.tf
+}
+
+// applyTransform updates the circle with its transformed center and radius.
+func (c *circle) applyTransform() {
+
+	center := c.tf.apply()
+	c.center = newPoint(center[0].x, center[0].y)
+	c.radius = c.tf.radius()
+}
+
+// Rotate rotates the circle by the specified angle, in radians, around its
+// origin.
+func (c *circle) Rotate(angle float64) {
+
+	rotateCollider(c, angle)
+}
+
+// SetRotation sets the rotation of the circle, in radians, around its origin.
+func (c *circle) SetRotation(angle float64) {
+
+	setColliderRotation(c, angle)
+}
+
+// Rotation returns the rotation of the circle in radians.
+func (c *circle) Rotation() float64 {
+
+	if c.tf == nil {
+		return 0
+	}
+
+	return c.tf.rotation
+}
+
+// SetOrigin sets the point, relative to the circle's center, that rotation
+// and scaling happen around.
+func (c *circle) SetOrigin(x, y float64) {
+
+	setColliderOrigin(c, x, y)
+}
+
+// SetScale sets the scaling factor of the circle. Circles cannot be
+// stretched, so the radius is scaled by the larger of the two values.
+func (c *circle) SetScale(x, y float64) {
+
+	setColliderScale(c, x, y)
+}
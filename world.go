@@ -0,0 +1,564 @@
+package paunch
+
+import (
+	"math"
+	"sort"
+)
+
+// defaultIterations is the number of times a World solves its contacts each
+// step unless told otherwise.
+const defaultIterations = 8
+
+// Contact describes two Colliders of two RigidBodies in a World that are
+// touching.
+type Contact struct {
+	Body1, Body2         *RigidBody
+	Collider1, Collider2 Collider
+	// Manifold describes how the Colliders overlap. Its normal points from
+	// the first body toward the second.
+	Manifold Manifold
+}
+
+// ContactCallback is a function that is told about a Contact between two
+// RigidBodies in a World.
+type ContactCallback func(contact Contact)
+
+// contactKey identifies a Contact between the same Colliders from one step to
+// the next.
+type contactKey struct {
+	body1, body2         *RigidBody
+	collider1, collider2 Collider
+}
+
+// World moves a set of RigidBodies together, pushing apart the bodies that
+// collide and holding together the bodies whose Physics objects are connected
+// by Joints. Every step, the bodies are moved forward in time, the bodies
+// that overlap are found with a broad phase and the resulting contacts and
+// the Joints are solved several times, so that the impulses between stacked
+// and connected bodies settle.
+type World struct {
+	Bodies []*RigidBody
+	Joints []Joint
+
+	gravity    physicsPoint
+	iterations int
+
+	sleepSpeed float64
+	sleepTime  float64
+
+	broadPhase BroadPhase
+	cellSize   float64
+	index      broadPhaseIndex
+	proxies    map[*RigidBody][]*proxy
+
+	contactBegin   ContactCallback
+	contactPersist ContactCallback
+	contactEnd     ContactCallback
+	contacts       map[contactKey]Contact
+	// impulses are the impulses applied along the normal and along the
+	// surface of each solved Contact during the last step.
+	impulses map[contactKey][2]float64
+}
+
+// NewWorld creates a new World with no gravity.
+func NewWorld() *World {
+
+	return &World{iterations: defaultIterations}
+}
+
+// SetGravity sets the acceleration, in units per second squared, that pulls
+// every dynamic body in the World.
+func (world *World) SetGravity(x, y float64) {
+
+	world.gravity = physicsPoint{x, y}
+}
+
+// Gravity returns the acceleration that pulls every dynamic body in the
+// World.
+func (world *World) Gravity() (float64, float64) {
+
+	return world.gravity.x, world.gravity.y
+}
+
+// SetIterations sets the number of times the contacts between bodies are
+// solved each step. More iterations make stacks of bodies steadier, at the
+// cost of speed. The default is 8.
+func (world *World) SetIterations(iterations int) {
+
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	world.iterations = iterations
+}
+
+// SetBroadPhase sets the strategy the World uses to avoid testing bodies that
+// are too far apart to collide. The default is AABBTree.
+func (world *World) SetBroadPhase(strategy BroadPhase) {
+
+	world.broadPhase = strategy
+	world.index, world.proxies = nil, nil
+}
+
+// SetCellSize sets the width and height of the cells used by the SpatialHash
+// broad phase strategy. Cells should be about the size of a typical body.
+func (world *World) SetCellSize(size float64) {
+
+	world.cellSize = size
+	world.index, world.proxies = nil, nil
+}
+
+// EnableSleeping makes dynamic bodies fall asleep once they have moved slower
+// than the specified speed, in units per second, for the specified number of
+// seconds. Sleeping bodies are not moved until another moving body touches
+// them or they are woken up with the WakeUp method, which saves time in
+// worlds with many resting bodies.
+func (world *World) EnableSleeping(speed, duration float64) {
+
+	world.sleepSpeed = speed
+	world.sleepTime = duration
+}
+
+// DisableSleeping stops bodies from falling asleep and wakes up any that are
+// sleeping.
+func (world *World) DisableSleeping() {
+
+	world.sleepSpeed, world.sleepTime = 0, 0
+	for _, val := range world.Bodies {
+		val.WakeUp()
+	}
+}
+
+// SetContactBeginCallback sets the function that is called when two
+// Colliders of the World's bodies start touching.
+func (world *World) SetContactBeginCallback(callback ContactCallback) {
+
+	world.contactBegin = callback
+}
+
+// SetContactPersistCallback sets the function that is called every step that
+// two Colliders of the World's bodies continue to touch.
+func (world *World) SetContactPersistCallback(callback ContactCallback) {
+
+	world.contactPersist = callback
+}
+
+// SetContactEndCallback sets the function that is called when two Colliders
+// of the World's bodies stop touching. The Manifold of the Contact is the one
+// from the last step the Colliders were touching.
+func (world *World) SetContactEndCallback(callback ContactCallback) {
+
+	world.contactEnd = callback
+}
+
+// updateIndex brings the broad phase index up to date with the Colliders of
+// the World's bodies.
+func (world *World) updateIndex() {
+
+	if world.index == nil {
+		world.index = newBroadPhaseIndex(world.broadPhase, world.cellSize)
+		world.proxies = make(map[*RigidBody][]*proxy)
+	}
+
+	present := make(map[*RigidBody]bool, len(world.Bodies))
+
+	for i, body := range world.Bodies {
+		if body == nil || present[body] {
+			continue
+		}
+		present[body] = true
+
+		static := body.bodyType == Static
+		proxies, tracked := world.proxies[body]
+		if tracked && len(proxies) == len(body.Colliders) {
+			same := true
+			for j, val := range proxies {
+				if val.collider != body.Colliders[j] || val.static != static {
+					same = false
+					break
+				}
+			}
+
+			if same {
+				for _, val := range proxies {
+					val.objectIndex = i
+					if val.refreshBounds() {
+						world.index.update(val)
+					}
+				}
+				continue
+			}
+		}
+
+		for _, val := range proxies {
+			world.index.remove(val)
+		}
+
+		proxies = make([]*proxy, len(body.Colliders))
+		for j, val := range body.Colliders {
+			proxies[j] = newProxy(body, val, j, static)
+			proxies[j].objectIndex = i
+			world.index.insert(proxies[j])
+		}
+		world.proxies[body] = proxies
+	}
+
+	for body, proxies := range world.proxies {
+		if !present[body] {
+			for _, val := range proxies {
+				world.index.remove(val)
+			}
+			delete(world.proxies, body)
+		}
+	}
+}
+
+// isActive returns true if the body moves on its own, so that it needs to be
+// tested against the bodies around it.
+func isActive(body *RigidBody) bool {
+
+	return body.bodyType != Static && !body.sleeping
+}
+
+// activeJoints returns the Joints connected to at least one awake body,
+// waking up the sleeping bodies that are connected to moving ones. Physics
+// objects that do not belong to a RigidBody may move at any time, so Joints
+// connected to them are always active and keep their bodies awake.
+func (world *World) activeJoints() []Joint {
+
+	var joints []Joint
+	for _, val := range world.Joints {
+		// The constructors never leave the first object nil, but a Joint's
+		// zero value connects nothing
+		physics1, physics2 := val.PhysicsObjects()
+		if physics1 == nil {
+			continue
+		}
+		body1, body2 := physics1.rigidBody(), physics2.rigidBody()
+
+		switch {
+		case body1 == nil || (physics2 != nil && body2 == nil):
+			if body1 != nil {
+				body1.WakeUp()
+			}
+			if body2 != nil {
+				body2.WakeUp()
+			}
+			joints = append(joints, val)
+		case body2 == nil:
+			if isActive(body1) {
+				joints = append(joints, val)
+			}
+		default:
+			wakeByContact(body1, body2)
+			wakeByContact(body2, body1)
+			if isActive(body1) || isActive(body2) {
+				joints = append(joints, val)
+			}
+		}
+	}
+
+	return joints
+}
+
+// findContacts returns the Contacts between the Colliders of the World's
+// bodies, in the order the bodies and their Colliders were supplied in.
+// Sleeping bodies that are touched by awake bodies are woken up.
+func (world *World) findContacts() []Contact {
+
+	type candidate struct {
+		proxy1 *proxy
+		proxy2 *proxy
+	}
+
+	world.updateIndex()
+
+	var candidates []candidate
+	for _, body := range world.Bodies {
+		if body == nil || !isActive(body) {
+			continue
+		}
+
+		for _, proxy1 := range world.proxies[body] {
+			world.index.query(proxy1.minX, proxy1.minY, proxy1.maxX, proxy1.maxY, func(proxy2 *proxy) {
+				other := proxy2.object.(*RigidBody)
+				if other == body {
+					return
+				}
+				// Pairs of active bodies are found from both sides, so
+				// only keep one of them
+				if isActive(other) && proxy2.objectIndex < proxy1.objectIndex {
+					return
+				}
+
+				if proxy2.objectIndex < proxy1.objectIndex {
+					candidates = append(candidates, candidate{proxy2, proxy1})
+				} else {
+					candidates = append(candidates, candidate{proxy1, proxy2})
+				}
+			})
+		}
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].proxy1.objectIndex != candidates[b].proxy1.objectIndex {
+			return candidates[a].proxy1.objectIndex < candidates[b].proxy1.objectIndex
+		}
+		if candidates[a].proxy2.objectIndex != candidates[b].proxy2.objectIndex {
+			return candidates[a].proxy2.objectIndex < candidates[b].proxy2.objectIndex
+		}
+		if candidates[a].proxy1.colliderIndex != candidates[b].proxy1.colliderIndex {
+			return candidates[a].proxy1.colliderIndex < candidates[b].proxy1.colliderIndex
+		}
+		return candidates[a].proxy2.colliderIndex < candidates[b].proxy2.colliderIndex
+	})
+
+	var contacts []Contact
+	for _, val := range candidates {
+		if !canCollide(val.proxy1.collider, val.proxy2.collider) {
+			continue
+		}
+
+		manifold, ok := CollisionManifold(val.proxy1.collider, val.proxy2.collider)
+		if !ok {
+			continue
+		}
+
+		body1, body2 := val.proxy1.object.(*RigidBody), val.proxy2.object.(*RigidBody)
+		wakeByContact(body1, body2)
+		wakeByContact(body2, body1)
+
+		contacts = append(contacts, Contact{body1, body2, val.proxy1.collider, val.proxy2.collider, manifold})
+	}
+
+	return contacts
+}
+
+// wakeByContact wakes the body up if it is sleeping and the other body,
+// which it is touching, was moving during the last step. Bodies that are
+// settling down do not wake their neighbors, so that stacks of bodies can
+// fall asleep.
+func wakeByContact(body, other *RigidBody) {
+
+	if body.sleeping && !other.sleeping && other.bodyType != Static && other.stillTime == 0 {
+		body.WakeUp()
+	}
+}
+
+// updateSleeping puts dynamic bodies that have been moving slowly for long
+// enough to sleep.
+func (world *World) updateSleeping(dt float64) {
+
+	if world.sleepTime <= 0 {
+		return
+	}
+
+	for _, body := range world.Bodies {
+		if body == nil || body.bodyType != Dynamic || body.sleeping {
+			continue
+		}
+
+		if math.Hypot(body.Physics.velocity.x, body.Physics.velocity.y) > world.sleepSpeed {
+			body.stillTime = 0
+			continue
+		}
+
+		body.stillTime += dt
+		if body.stillTime >= world.sleepTime {
+			body.sleeping = true
+			body.Physics.velocity = physicsPoint{}
+		}
+	}
+}
+
+// reportContacts calls the World's contact callbacks for the Contacts found
+// this step and the Contacts that have ended since the last step.
+func (world *World) reportContacts(contacts []Contact) {
+
+	current := make(map[contactKey]Contact, len(contacts))
+	for _, val := range contacts {
+		key := contactKey{val.Body1, val.Body2, val.Collider1, val.Collider2}
+		current[key] = val
+
+		if _, ok := world.contacts[key]; ok {
+			if world.contactPersist != nil {
+				world.contactPersist(val)
+			}
+		} else if world.contactBegin != nil {
+			world.contactBegin(val)
+		}
+	}
+
+	var ended []Contact
+	for key, val := range world.contacts {
+		if _, ok := current[key]; ok {
+			continue
+		}
+
+		// Contacts with sleeping bodies continue until they wake up
+		if (key.body1.sleeping || key.body1.bodyType == Static) &&
+			(key.body2.sleeping || key.body2.bodyType == Static) {
+			current[key] = val
+			continue
+		}
+		ended = append(ended, val)
+	}
+
+	if world.contactEnd != nil {
+		world.sortContacts(ended)
+		for _, val := range ended {
+			world.contactEnd(val)
+		}
+	}
+
+	world.contacts = current
+}
+
+// sortContacts sorts the Contacts in the order that findContacts returns
+// them, by the positions of their bodies in the World's bodies and then by
+// the positions of their Colliders in the bodies' Colliders. Bodies and
+// Colliders that have been removed come first.
+func (world *World) sortContacts(contacts []Contact) {
+
+	if len(contacts) < 2 {
+		return
+	}
+
+	bodies := make(map[*RigidBody]int, len(world.Bodies))
+	colliders := make(map[Collider]int)
+	for i, body := range world.Bodies {
+		if _, ok := bodies[body]; body == nil || ok {
+			continue
+		}
+		bodies[body] = i
+		for j, val := range body.Colliders {
+			if _, ok := colliders[val]; !ok {
+				colliders[val] = j
+			}
+		}
+	}
+
+	position := func(contact Contact) [4]int {
+		key := [4]int{-1, -1, -1, -1}
+		if i, ok := bodies[contact.Body1]; ok {
+			key[0] = i
+		}
+		if i, ok := bodies[contact.Body2]; ok {
+			key[1] = i
+		}
+		if i, ok := colliders[contact.Collider1]; ok {
+			key[2] = i
+		}
+		if i, ok := colliders[contact.Collider2]; ok {
+			key[3] = i
+		}
+		return key
+	}
+
+	sort.Slice(contacts, func(a, b int) bool {
+		key1, key2 := position(contacts[a]), position(contacts[b])
+		for i := range key1 {
+			if key1[i] != key2[i] {
+				return key1[i] < key2[i]
+			}
+		}
+		return false
+	})
+}
+
+// Step moves the World forward in time by dt seconds. Each awake body is
+// pulled by gravity and by any springs, and moved with its Step method. Then,
+// the contacts between the bodies are found and, along with the Joints,
+// solved the number of times set with SetIterations, exchanging momentum
+// between the bodies, before overlapping bodies are pushed apart and
+// connected bodies are moved back into place. Contacts involving sensors are
+// reported to the contact callbacks but never solved, and Colliders whose
+// collision categories and masks do not match are ignored.
+func (world *World) Step(dt float64) {
+
+	if dt <= 0 {
+		return
+	}
+
+	joints := world.activeJoints()
+	for _, val := range joints {
+		val.applyForce(dt)
+	}
+
+	for _, body := range world.Bodies {
+		if body == nil || !isActive(body) {
+			continue
+		}
+
+		if body.bodyType == Dynamic {
+			body.Physics.Accelerate(world.gravity.x, world.gravity.y)
+		}
+		body.Step(dt)
+	}
+
+	contacts := world.findContacts()
+
+	var solved []Contact
+	for _, val := range contacts {
+		if isResolvable(val.Collider1, val.Collider2) {
+			solved = append(solved, val)
+		}
+	}
+
+	// Contacts that continue from the last step start with the impulses they
+	// ended it with
+	constraints := make([]*contactConstraint, len(solved))
+	for i, val := range solved {
+		constraints[i] = newContactConstraint(val.Body1, val.Body2, val.Manifold)
+		key := contactKey{val.Body1, val.Body2, val.Collider1, val.Collider2}
+		if impulses, ok := world.impulses[key]; ok {
+			constraints[i].warmStart(impulses[0], impulses[1])
+		}
+	}
+
+	for i := 0; i < world.iterations; i++ {
+		for _, val := range constraints {
+			val.solve()
+		}
+		for _, val := range joints {
+			val.solveVelocity()
+		}
+	}
+
+	world.impulses = make(map[contactKey][2]float64, len(solved))
+	for i, val := range solved {
+		key := contactKey{val.Body1, val.Body2, val.Collider1, val.Collider2}
+		world.impulses[key] = [2]float64{constraints[i].normalImpulse, constraints[i].tangentImpulse}
+	}
+
+	// Pushing one pair of bodies apart can push one of them into a third, so
+	// overlaps are corrected several times. The depth of each Contact is
+	// reduced by how far its bodies have already been pushed apart.
+	moved := make(map[*RigidBody]physicsPoint)
+	for i := 0; i < world.iterations; i++ {
+		for _, val := range solved {
+			manifold := val.Manifold
+			moved1, moved2 := moved[val.Body1], moved[val.Body2]
+			manifold.Depth -= ((moved2.x - moved1.x) * manifold.NormalX) + ((moved2.y - moved1.y) * manifold.NormalY)
+
+			correction := correctPosition(val.Body1, val.Body2, manifold)
+			if correction == 0 {
+				continue
+			}
+
+			invMass1, invMass2 := val.Body1.inverseMass(), val.Body2.inverseMass()
+			moved[val.Body1] = physicsPoint{moved1.x - (correction * invMass1 * manifold.NormalX),
+				moved1.y - (correction * invMass1 * manifold.NormalY)}
+			moved[val.Body2] = physicsPoint{moved2.x + (correction * invMass2 * manifold.NormalX),
+				moved2.y + (correction * invMass2 * manifold.NormalY)}
+		}
+	}
+	for i := 0; i < world.iterations; i++ {
+		for _, val := range joints {
+			val.solvePosition()
+		}
+	}
+
+	world.updateSleeping(dt)
+	world.reportContacts(contacts)
+}
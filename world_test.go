@@ -0,0 +1,85 @@
+package paunch
+
+import (
+	"testing"
+)
+
+func TestWorldStackSleeps(t *testing.T) {
+
+	world := NewWorld()
+	world.SetGravity(0, -100)
+	world.EnableSleeping(1, 0.5)
+
+	ground := NewRigidBody(Static, NewCollider([]float64{-50, -10, 50, -10, 50, 0, -50, 0}))
+	world.Bodies = append(world.Bodies, ground)
+
+	var boxes []*RigidBody
+	for i := 0; i < 5; i++ {
+		y := float64(i) * 10
+		box := NewRigidBody(Dynamic, NewCollider([]float64{0, y, 10, y, 10, y + 10, 0, y + 10}))
+		boxes = append(boxes, box)
+		world.Bodies = append(world.Bodies, box)
+	}
+
+	for i := 0; i < 600; i++ {
+		world.Step(1.0 / 60)
+	}
+
+	for i, val := range boxes {
+		if !val.Sleeping() {
+			velX, velY := val.Physics.Velocity()
+			t.Errorf("box %d is awake with a velocity of %v, %v", i, velX, velY)
+		}
+
+		_, y := val.Colliders[0].Position()
+		if want := float64(i) * 10; y < want-1 || y > want+1 {
+			t.Errorf("box %d rests at a height of %v, want about %v", i, y, want)
+		}
+	}
+}
+
+func TestWorldContactEndOrder(t *testing.T) {
+
+	world := NewWorld()
+
+	wall := NewRigidBody(Static, NewCollider([]float64{0, 0, 10, 0, 10, 10, 0, 10}),
+		NewCollider([]float64{10, 0, 20, 0, 20, 10, 10, 10}))
+	world.Bodies = append(world.Bodies, wall)
+
+	var boxes []*RigidBody
+	for i := 0; i < 3; i++ {
+		y := float64(i) * 3
+		box := NewRigidBody(Dynamic, NewCollider([]float64{5, y, 15, y, 15, y + 1, 5, y + 1}),
+			NewCircleCollider(10, y+0.5, 1))
+		boxes = append(boxes, box)
+		world.Bodies = append(world.Bodies, box)
+	}
+
+	var begun, ended []Contact
+	world.SetContactBeginCallback(func(contact Contact) {
+		begun = append(begun, contact)
+	})
+	world.SetContactEndCallback(func(contact Contact) {
+		ended = append(ended, contact)
+	})
+
+	world.Step(1.0 / 60)
+	if len(begun) != 12 {
+		t.Fatalf("%d contacts began, want 12", len(begun))
+	}
+
+	for i, val := range boxes {
+		val.Physics.Move(100, float64(i)*100)
+	}
+	world.Step(1.0 / 60)
+
+	if len(ended) != len(begun) {
+		t.Fatalf("%d contacts ended, want %d", len(ended), len(begun))
+	}
+	for i, val := range ended {
+		if val.Body1 != begun[i].Body1 || val.Body2 != begun[i].Body2 ||
+			val.Collider1 != begun[i].Collider1 || val.Collider2 != begun[i].Collider2 {
+			t.Errorf("contact %d ended out of order", i)
+		}
+	}
+}